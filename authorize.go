@@ -0,0 +1,116 @@
+package slashparse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrPermissionDenied is returned when the caller is not allowed to run a command
+var ErrPermissionDenied = errors.New("permission denied")
+
+// Requirement is the roles and permissions declared on a command. Having any one of them is enough to run it.
+type Requirement struct {
+	Roles       []string
+	Permissions []string
+}
+
+func (r Requirement) isEmpty() bool {
+	return len(r.Roles) == 0 && len(r.Permissions) == 0
+}
+
+// Authorizer decides if the user making a request meets a requirement of the command at commandPath.
+// It is called once for each level of the command path that declares roles or permissions.
+type Authorizer interface {
+	Authorize(ctx context.Context, req Request, commandPath string, requirement Requirement) (bool, error)
+}
+
+// AuthorizerFunc lets an ordinary function be used as an Authorizer
+type AuthorizerFunc func(ctx context.Context, req Request, commandPath string, requirement Requirement) (bool, error)
+
+// Authorize calls f
+func (f AuthorizerFunc) Authorize(ctx context.Context, req Request, commandPath string, requirement Requirement) (bool, error) {
+	return f(ctx, req, commandPath, requirement)
+}
+
+// SetAuthorizer sets the Authorizer used to enforce roles and permissions
+func (s *SlashCommand) SetAuthorizer(authorizer Authorizer) {
+	s.authorizer = authorizer
+}
+
+// PermissionDeniedResponse is the response sent when the caller can't run the command
+func PermissionDeniedResponse(commandPath string) Response {
+	return Response{
+		Text:       fmt.Sprintf("You do not have permission to run /%s.", commandPath),
+		Visibility: VisibilityEphemeral,
+	}
+}
+
+// getRequirements returns the requirements declared along a command path
+func (s *SlashCommand) getRequirements(commandString string) []Requirement {
+	var requirements []Requirement
+
+	requirement := Requirement{Roles: s.Roles, Permissions: s.Permissions}
+	if !requirement.isEmpty() {
+		requirements = append(requirements, requirement)
+	}
+
+	for _, subCommand := range s.getSubCommandChain(commandString) {
+		requirement := Requirement{Roles: subCommand.Roles, Permissions: subCommand.Permissions}
+		if !requirement.isEmpty() {
+			requirements = append(requirements, requirement)
+		}
+	}
+	return requirements
+}
+
+// canRun checks every requirement along the command path with the authorizer
+func (s *SlashCommand) canRun(ctx context.Context, req Request, commandString string) (bool, error) {
+	for _, requirement := range s.getRequirements(commandString) {
+		if s.authorizer == nil {
+			return false, fmt.Errorf("/%s declares roles or permissions but no Authorizer is set", commandString)
+		}
+
+		allowed, err := s.authorizer.Authorize(ctx, req, commandString, requirement)
+		if err != nil || !allowed {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// authorize wraps a handler so it only runs when the caller meets the command's requirements
+func (s *SlashCommand) authorize(commandString string, next Handler) Handler {
+	return func(ctx context.Context, req Request, values map[string]string) (Response, error) {
+		allowed, err := s.canRun(ctx, req, commandString)
+		if err != nil {
+			return Response{}, err
+		}
+		if !allowed {
+			return PermissionDeniedResponse(commandString), ErrPermissionDenied
+		}
+		return next(ctx, req, values)
+	}
+}
+
+// allowedCommands returns a copy of the slash command without the sub commands the caller can't run
+func (s *SlashCommand) allowedCommands(ctx context.Context, req Request) SlashCommand {
+	allowed := *s
+	allowed.SubCommands = make([]SubCommand, 0, len(s.SubCommands))
+
+	for _, subCommand := range s.SubCommands {
+		if ok, _ := s.canRun(ctx, req, subCommand.getCommandPath()); !ok {
+			continue
+		}
+
+		subSubCommands := make([]SubCommand, 0, len(subCommand.SubCommands))
+		for _, subSubCommand := range subCommand.SubCommands {
+			if ok, _ := s.canRun(ctx, req, subSubCommand.getCommandPath()); ok {
+				subSubCommands = append(subSubCommands, subSubCommand)
+			}
+		}
+		subCommand.SubCommands = subSubCommands
+		allowed.SubCommands = append(allowed.SubCommands, subCommand)
+	}
+	return allowed
+}
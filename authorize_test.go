@@ -0,0 +1,108 @@
+package slashparse
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var securedDef, _ = ioutil.ReadFile("./testData/secured.yaml")
+
+// roleAuthorizer grants access when the user has one of the required roles or permissions
+func roleAuthorizer(grants map[string][]string) Authorizer {
+	return AuthorizerFunc(func(ctx context.Context, req Request, commandPath string, requirement Requirement) (bool, error) {
+		for _, grant := range grants[req.UserID] {
+			for _, role := range append(requirement.Roles, requirement.Permissions...) {
+				if grant == role {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	})
+}
+
+type authorizeTests struct {
+	name          string
+	userID        string
+	commandString string
+	want          Response
+	wantErr       error
+}
+
+func TestAuthorize(t *testing.T) {
+	authorizer := roleAuthorizer(map[string][]string{
+		"admin":     {"system_admin"},
+		"moderator": {"manage_others_posts"},
+	})
+
+	tests := []authorizeTests{
+		{
+			name:          "role allows command",
+			userID:        "admin",
+			commandString: "/wrangler move thread abc",
+			want:          TextResponse("moved abc"),
+		},
+		{
+			name:          "permission allows command",
+			userID:        "moderator",
+			commandString: "/wrangler move thread abc",
+			want:          TextResponse("moved abc"),
+		},
+		{
+			name:          "requirement of parent command is enforced",
+			userID:        "guest",
+			commandString: "/wrangler move thread abc",
+			want:          PermissionDeniedResponse("wrangler move thread"),
+			wantErr:       ErrPermissionDenied,
+		},
+		{
+			name:          "commands without requirements are open",
+			userID:        "guest",
+			commandString: "/wrangler list channels",
+			want:          TextResponse("channels"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newSlash, _ := NewSlashCommand(securedDef)
+			newSlash.SetAuthorizer(authorizer)
+			_ = newSlash.SetHandler("wrangler move thread", func(values map[string]string) (string, error) {
+				return "moved " + values["messageID"], nil
+			})
+			_ = newSlash.SetHandler("wrangler list channels", func(values map[string]string) (string, error) {
+				return "channels", nil
+			})
+
+			got, err := newSlash.ExecuteContext(context.Background(), Request{UserID: test.userID, Text: test.commandString})
+
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("missing authorizer denies", func(t *testing.T) {
+		newSlash, _ := NewSlashCommand(securedDef)
+		_, err := newSlash.Execute("/wrangler move thread abc")
+
+		assert.EqualError(t, err, "/wrangler move thread declares roles or permissions but no Authorizer is set")
+	})
+}
+
+func TestHelpHidesUnauthorizedCommands(t *testing.T) {
+	newSlash, _ := NewSlashCommand(securedDef)
+	newSlash.SetAuthorizer(roleAuthorizer(map[string][]string{"admin": {"system_admin"}}))
+
+	adminHelp, _ := newSlash.ExecuteContext(context.Background(), Request{UserID: "admin", Text: "/wrangler help"})
+	guestHelp, _ := newSlash.ExecuteContext(context.Background(), Request{UserID: "guest", Text: "/wrangler help"})
+
+	assert.True(t, strings.Contains(adminHelp.Text, "**move**"))
+	assert.True(t, strings.Contains(adminHelp.Text, "**teams**"))
+	assert.False(t, strings.Contains(guestHelp.Text, "**move**"))
+	assert.False(t, strings.Contains(guestHelp.Text, "**teams**"))
+	assert.True(t, strings.Contains(guestHelp.Text, "**channels**"))
+}
@@ -0,0 +1,175 @@
+package slashparse
+
+import (
+	"sort"
+	"strings"
+)
+
+// AutocompleteArgType is the kind of input Mattermost autocompletes for an argument
+type AutocompleteArgType string
+
+const (
+	// AutocompleteArgTypeText is free text input
+	AutocompleteArgTypeText AutocompleteArgType = "TextInput"
+	// AutocompleteArgTypeStaticList is a fixed list of choices
+	AutocompleteArgTypeStaticList AutocompleteArgType = "StaticList"
+	// AutocompleteArgTypeDynamicList is a list of choices fetched from a url
+	AutocompleteArgTypeDynamicList AutocompleteArgType = "DynamicList"
+)
+
+// AutocompleteData mirrors Mattermost's model.AutocompleteData. The field names match,
+// so it can be copied field by field or converted with json to register a command.
+type AutocompleteData struct {
+	Trigger     string
+	Hint        string
+	HelpText    string
+	RoleID      string
+	Arguments   []*AutocompleteArg
+	SubCommands []*AutocompleteData
+}
+
+// AutocompleteArg mirrors Mattermost's model.AutocompleteArg. Name is empty for positional arguments.
+// Data is an *AutocompleteTextArg, *AutocompleteStaticListArg or *AutocompleteDynamicListArg depending on Type.
+type AutocompleteArg struct {
+	Name     string
+	HelpText string
+	Type     AutocompleteArgType
+	Required bool
+	Data     interface{}
+}
+
+// AutocompleteTextArg mirrors Mattermost's model.AutocompleteTextArg
+type AutocompleteTextArg struct {
+	Hint    string
+	Pattern string
+}
+
+// AutocompleteStaticListArg mirrors Mattermost's model.AutocompleteStaticListArg
+type AutocompleteStaticListArg struct {
+	PossibleArguments []AutocompleteListItem
+}
+
+// AutocompleteDynamicListArg mirrors Mattermost's model.AutocompleteDynamicListArg
+type AutocompleteDynamicListArg struct {
+	FetchURL string
+}
+
+// AutocompleteListItem mirrors Mattermost's model.AutocompleteListItem
+type AutocompleteListItem struct {
+	Item     string
+	Hint     string
+	HelpText string
+}
+
+const (
+	autocompleteSystemAdminRole = "system_admin"
+	autocompleteSystemUserRole  = "system_user"
+)
+
+// GetAutocompleteData converts the slash command into Mattermost autocomplete data, so the
+// command can be registered with rich autocomplete from the same definition. Hidden commands and
+// arguments are left out, a hidden slash command has no autocomplete data so it returns nil. Required and
+// remaining text arguments are positional, the rest are named.
+func (s *SlashCommand) GetAutocompleteData() *AutocompleteData {
+	if s.Hidden {
+		return nil
+	}
+	return newAutocompleteData(strings.ToLower(s.Name), s.asSubCommand())
+}
+
+func newAutocompleteData(trigger string, command SubCommand) *AutocompleteData {
+	data := &AutocompleteData{
+		Trigger:  trigger,
+		Hint:     getCommandHint(command),
+		HelpText: command.Description,
+		RoleID:   getAutocompleteRole(command),
+	}
+	if command.Deprecated != "" {
+		data.HelpText = "(deprecated) " + data.HelpText
+	}
+
+	arguments := visibleArguments(command.Arguments)
+	sort.SliceStable(arguments, func(i, j int) bool {
+		return arguments[i].Position < arguments[j].Position
+	})
+	for _, arg := range arguments {
+		data.Arguments = append(data.Arguments, newAutocompleteArg(arg))
+	}
+
+	for _, subCommand := range command.SubCommands {
+		if subCommand.Hidden {
+			continue
+		}
+		data.SubCommands = append(data.SubCommands, newAutocompleteData(subCommand.Name, subCommand))
+	}
+	return data
+}
+
+func newAutocompleteArg(arg Argument) *AutocompleteArg {
+	autocompleteArg := &AutocompleteArg{
+		HelpText: arg.Description,
+		Required: arg.Required,
+	}
+	if !isPositionalArgument(arg) {
+		autocompleteArg.Name = arg.Name
+	}
+
+	switch {
+	case len(arg.Choices) > 0:
+		autocompleteArg.Type = AutocompleteArgTypeStaticList
+		list := &AutocompleteStaticListArg{}
+		for _, choice := range arg.Choices {
+			list.PossibleArguments = append(list.PossibleArguments, AutocompleteListItem{
+				Item:     choice.Value,
+				Hint:     choice.Value,
+				HelpText: choice.Description,
+			})
+		}
+		autocompleteArg.Data = list
+	case arg.ChoicesURL != "":
+		autocompleteArg.Type = AutocompleteArgTypeDynamicList
+		autocompleteArg.Data = &AutocompleteDynamicListArg{FetchURL: arg.ChoicesURL}
+	default:
+		autocompleteArg.Type = AutocompleteArgTypeText
+		textArg := &AutocompleteTextArg{Hint: arg.Hint}
+		if textArg.Hint == "" {
+			textArg.Hint = arg.Name
+		}
+		if arg.ArgType == "number" {
+			textArg.Pattern = "^[0-9]+$"
+		}
+		autocompleteArg.Data = textArg
+	}
+	return autocompleteArg
+}
+
+// isPositionalArgument decides how an argument is autocompleted, users are expected to pass
+// required and remaining text arguments by position and optional ones by name
+func isPositionalArgument(arg Argument) bool {
+	return arg.Required || arg.ArgType == "remaining text"
+}
+
+// getCommandHint is the hint shown after a command name while autocompleting
+func getCommandHint(command SubCommand) string {
+	for _, subCommand := range command.SubCommands {
+		if !subCommand.Hidden {
+			return "[command]"
+		}
+	}
+
+	usage := getUsage("", visibleArguments(command.Arguments))
+	return strings.TrimSpace(strings.TrimPrefix(usage, "/"))
+}
+
+// getAutocompleteRole maps the roles of a command onto the role Mattermost restricts autocomplete to
+func getAutocompleteRole(command SubCommand) string {
+	if len(command.Permissions) > 0 || len(command.Roles) == 0 {
+		return autocompleteSystemUserRole
+	}
+	for _, role := range command.Roles {
+		if role != autocompleteSystemAdminRole {
+			return autocompleteSystemUserRole
+		}
+	}
+	return autocompleteSystemAdminRole
+}
@@ -0,0 +1,113 @@
+package slashparse
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var todoDef, _ = ioutil.ReadFile("./testData/todo.yaml")
+
+func TestGetAutocompleteData(t *testing.T) {
+	newSlash, _ := NewSlashCommand(todoDef)
+	got := newSlash.GetAutocompleteData()
+
+	assert.Equal(t, "todo", got.Trigger)
+	assert.Equal(t, "[command]", got.Hint)
+	assert.Equal(t, "Keep track of things to do", got.HelpText)
+	assert.Equal(t, "system_user", got.RoleID)
+	assert.Len(t, got.SubCommands, 5)
+
+	add := got.SubCommands[0]
+	assert.Equal(t, "add", add.Trigger)
+	assert.Equal(t, "message", add.Hint)
+	assert.Equal(t, []*AutocompleteArg{
+		{
+			HelpText: "What needs to be done",
+			Type:     AutocompleteArgTypeText,
+			Required: true,
+			Data:     &AutocompleteTextArg{Hint: "message"},
+		},
+	}, add.Arguments)
+
+	list := got.SubCommands[1]
+	assert.Equal(t, "[status]", list.Hint)
+	assert.Equal(t, &AutocompleteArg{
+		Name:     "status",
+		HelpText: "Which todos to list",
+		Type:     AutocompleteArgTypeStaticList,
+		Data: &AutocompleteStaticListArg{
+			PossibleArguments: []AutocompleteListItem{
+				{Item: "open", Hint: "open", HelpText: "Todos that still need doing"},
+				{Item: "done", Hint: "done", HelpText: "Todos that are finished"},
+				{Item: "all", Hint: "all"},
+			},
+		},
+	}, list.Arguments[0])
+
+	done := got.SubCommands[2]
+	assert.Equal(t, &AutocompleteTextArg{Hint: "todo ID", Pattern: "^[0-9]+$"}, done.Arguments[0].Data)
+
+	assign := got.SubCommands[3]
+	assert.Equal(t, "system_admin", assign.RoleID)
+	assert.Equal(t, "user", assign.Arguments[1].Name)
+	assert.Equal(t, AutocompleteArgTypeDynamicList, assign.Arguments[1].Type)
+	assert.Equal(t, &AutocompleteDynamicListArg{FetchURL: "plugins/todo/users"}, assign.Arguments[1].Data)
+
+	assert.Equal(t, "help", got.SubCommands[4].Trigger)
+}
+
+func TestAutocompleteSkipsHidden(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+	newSlash.SubCommands[0].Hidden = true
+	got := newSlash.GetAutocompleteData()
+
+	assert.Equal(t, "move", got.SubCommands[0].Trigger)
+
+	list := got.SubCommands[3]
+	messages := list.SubCommands[1]
+	names := []string{}
+	for _, arg := range messages.Arguments {
+		names = append(names, arg.Name)
+	}
+	assert.Equal(t, []string{"count", "trim-length", "trim"}, names)
+	assert.Equal(t, "(deprecated) Attach messages", got.SubCommands[2].SubCommands[0].HelpText)
+}
+
+func TestAutocompleteHiddenCommand(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+	newSlash.Hidden = true
+
+	assert.Nil(t, newSlash.GetAutocompleteData())
+}
+
+func TestAutocompleteDataJSON(t *testing.T) {
+	newSlash, _ := NewSlashCommand(todoDef)
+	got, err := json.Marshal(newSlash.GetAutocompleteData().SubCommands[2])
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"Trigger": "done",
+		"Hint": "id",
+		"HelpText": "Mark a todo as done",
+		"RoleID": "system_user",
+		"Arguments": [
+			{"Name": "", "HelpText": "The ID of the todo", "Type": "TextInput", "Required": true, "Data": {"Hint": "todo ID", "Pattern": "^[0-9]+$"}}
+		],
+		"SubCommands": null
+	}`, string(got))
+}
+
+func TestChoices(t *testing.T) {
+	newSlash, _ := NewSlashCommand(todoDef)
+
+	_, values, err := newSlash.Parse("/todo list --status DONE")
+	assert.Nil(t, err)
+	assert.Equal(t, "DONE", values["status"])
+
+	_, values, err = newSlash.Parse("/todo list someday")
+	assert.Nil(t, err)
+	assert.Equal(t, "someday", values["status"])
+}
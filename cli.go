@@ -0,0 +1,115 @@
+package slashparse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Exit codes returned by RunCLI
+const (
+	// ExitOK is returned when the command ran
+	ExitOK = 0
+	// ExitError is returned when the handler failed
+	ExitError = 1
+	// ExitUsage is returned when the command line is not a valid command
+	ExitUsage = 2
+	// ExitPermissionDenied is returned when the Authorizer denied the command
+	ExitPermissionDenied = 77
+)
+
+// RunCLI runs the slash command as a command line program, so a definition can also be an ops tool.
+// args are os.Args: the program name is replaced by the slash command name and the rest are used as
+// the tokens as the shell split them. The response or help is written to stdout and errors to stderr.
+// It returns ExitUsage for invalid command lines and ExitError when the handler fails.
+func (s *SlashCommand) RunCLI(args []string, stdout, stderr io.Writer) int {
+	tokens := []string{s.Name}
+	if len(args) > 1 {
+		tokens = append(tokens, args[1:]...)
+	}
+
+	ctx := context.Background()
+	req := Request{
+		Platform: "cli",
+		Text:     "/" + joinTokens(tokens),
+	}
+
+	if helpPath, ok := s.getHelpPathFromTokens(tokens); ok {
+		allowed := s.allowedCommands(ctx, req)
+		help, err := allowed.GetHelpAs(HelpFormatText, helpPath)
+		if err != nil {
+			fmt.Fprintln(stderr, s.cliMessage(err))
+			return ExitUsage
+		}
+		fmt.Fprint(stdout, help)
+		return ExitOK
+	}
+
+	commandString, values, err := s.parseTokens(tokens, req.ContextValues())
+	if err != nil {
+		fmt.Fprintln(stderr, s.cliMessage(err))
+		return ExitUsage
+	}
+
+	response, err := s.invokeHandler(ctx, req, commandString, values)
+	if err != nil {
+		message := response.Text
+		if message == "" {
+			message = err.Error()
+		}
+		fmt.Fprintln(stderr, message)
+		if errors.Is(err, ErrPermissionDenied) {
+			return ExitPermissionDenied
+		}
+		return ExitError
+	}
+
+	response = appendWarnings(response, s.getDeprecationWarnings(commandString, tokens[len(strings.Fields(commandString)):], nil))
+	if response.Text != "" {
+		fmt.Fprintln(stdout, response.Text)
+	}
+	return ExitOK
+}
+
+// parseTokens parses a command that is already split into tokens, the first being the slash command name
+func (s *SlashCommand) parseTokens(tokens []string, contextValues map[string]string) (string, map[string]string, error) {
+	commandString, err := s.getCommandString(strings.Join(tokens, " "))
+	if err != nil {
+		return "", nil, err
+	}
+
+	//the path is matched on the joined tokens, make sure a quoted token didn't make up part of it
+	path := strings.Fields(commandString)
+	for i, word := range path {
+		if i >= len(tokens) || !strings.EqualFold(strings.TrimPrefix(tokens[i], "/"), word) {
+			return "", nil, fmt.Errorf("/%s is not a valid command. Please see /%s help", strings.Join(tokens, " "), s.Name)
+		}
+	}
+
+	values, err := s.getCommandValues(commandString, tokens[len(path):], nil, contextValues)
+	if err != nil {
+		return "", nil, err
+	}
+	return commandString, values, nil
+}
+
+// cliMessage rewrites the slash commands an error mentions, such as "see /todo help", into command lines, "see todo help"
+func (s *SlashCommand) cliMessage(err error) string {
+	slashCommand := regexp.MustCompile(`(?i)(^|\s)/` + regexp.QuoteMeta(s.Name) + `\b`)
+	return slashCommand.ReplaceAllString(err.Error(), "${1}"+strings.ToLower(s.Name))
+}
+
+// joinTokens joins tokens into a slash string, quoting the ones with spaces
+func joinTokens(tokens []string) string {
+	quoted := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if strings.ContainsAny(token, " \t") {
+			token = `"` + token + `"`
+		}
+		quoted = append(quoted, token)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,96 @@
+package slashparse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunCLI(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "tokens are not split again",
+			args:       []string{"./todo", "add", "buy \"oat\" milk", "today"},
+			wantCode:   ExitOK,
+			wantStdout: "added buy \"oat\" milk today\n",
+		},
+		{
+			name:       "named argument",
+			args:       []string{"/usr/bin/todo", "list", "--status", "done"},
+			wantCode:   ExitOK,
+			wantStdout: "listing done todos\n",
+		},
+		{
+			name:       "missing argument",
+			args:       []string{"todo", "done"},
+			wantCode:   ExitUsage,
+			wantStderr: "required field id is missing, see todo help for more details\n",
+		},
+		{
+			name:       "sub command required",
+			args:       []string{"todo"},
+			wantCode:   ExitUsage,
+			wantStderr: "todo is not a valid command. Please see todo help\n",
+		},
+		{
+			name:       "handler error",
+			args:       []string{"todo", "done", "12"},
+			wantCode:   ExitError,
+			wantStderr: "todo 12 does not exist\n",
+		},
+		{
+			name:       "help",
+			args:       []string{"todo", "list", "--help"},
+			wantCode:   ExitOK,
+			wantStdout: "/todo list [status]",
+		},
+		{
+			name:       "help for an unknown command",
+			args:       []string{"todo", "help", "remove"},
+			wantCode:   ExitUsage,
+			wantStderr: "todo remove is not a valid command. Please see todo help\n",
+		},
+	}
+
+	newSlash, _ := NewSlashCommand(todoDef)
+	newSlash.SetHandler("todo add", func(values map[string]string) (string, error) {
+		return "added " + values["message"], nil
+	})
+	newSlash.SetHandler("todo list", func(values map[string]string) (string, error) {
+		return "listing " + values["status"] + " todos", nil
+	})
+	newSlash.SetHandler("todo done", func(values map[string]string) (string, error) {
+		return "", errors.New("todo " + values["id"] + " does not exist")
+	})
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			got := newSlash.RunCLI(test.args, &stdout, &stderr)
+			assert.Equal(t, test.wantCode, got)
+			assert.Contains(t, stdout.String(), test.wantStdout)
+			assert.Equal(t, test.wantStderr, stderr.String())
+		})
+	}
+}
+
+func TestRunCLIPermissionDenied(t *testing.T) {
+	newSlash, _ := NewSlashCommand(securedDef)
+	newSlash.SetAuthorizer(AuthorizerFunc(func(ctx context.Context, req Request, commandPath string, requirement Requirement) (bool, error) {
+		return false, nil
+	}))
+
+	var stdout, stderr bytes.Buffer
+	got := newSlash.RunCLI([]string{"wrangler", "move", "thread", "abc"}, &stdout, &stderr)
+	assert.Equal(t, ExitPermissionDenied, got)
+	assert.Equal(t, "You do not have permission to run /wrangler move thread.\n", stderr.String())
+}
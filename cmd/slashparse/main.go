@@ -0,0 +1,227 @@
+// Command slashparse works on slash command definitions without writing Go.
+//
+//	slashparse validate <file>
+//	slashparse lint <file>
+//	slashparse help <file> [path] [--format md|text|html|json]
+//	slashparse parse <file> "<input>"
+//	slashparse export <file> --target mattermost|slack|discord [--url url]
+//	slashparse generate <file> --package name [--out file]
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ericjaystevens/slashparse"
+	"github.com/ericjaystevens/slashparse/codegen"
+)
+
+// exit codes
+const (
+	exitOK      = 0
+	exitFailed  = 1
+	exitUsage   = 2
+	usageString = `usage:
+  slashparse validate <file>
+  slashparse lint <file>
+  slashparse help <file> [path] [--format md|text|html|json]
+  slashparse parse <file> "<input>"
+  slashparse export <file> --target mattermost|slack|discord [--url url]
+  slashparse generate <file> --package name [--out file]
+`
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usageString)
+		return exitUsage
+	}
+
+	positional, flags, err := parseFlags(args[1:], "format", "target", "url", "package", "out")
+	if err != nil || len(positional) == 0 {
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+		}
+		fmt.Fprint(stderr, usageString)
+		return exitUsage
+	}
+
+	command, err := slashparse.LoadSlashCommand(positional[0])
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		fmt.Fprintln(stderr, err)
+		return exitFailed
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "%s is not valid: %s\n", positional[0], err)
+		return exitFailed
+	}
+
+	switch args[0] {
+	case "validate":
+		fmt.Fprintf(stdout, "%s is valid\n", positional[0])
+		return exitOK
+	case "lint":
+		return lint(&command, stdout)
+	case "help":
+		return help(&command, positional[1:], flags["format"], stdout, stderr)
+	case "parse":
+		if len(positional) != 2 {
+			fmt.Fprint(stderr, usageString)
+			return exitUsage
+		}
+		return parse(&command, positional[1], stdout, stderr)
+	case "export":
+		return export(&command, flags["target"], flags["url"], stdout, stderr)
+	case "generate":
+		return generate(&command, positional[0], flags["package"], flags["out"], stdout, stderr)
+	}
+
+	fmt.Fprintf(stderr, "unknown command %s\n", args[0])
+	fmt.Fprint(stderr, usageString)
+	return exitUsage
+}
+
+func lint(command *slashparse.SlashCommand, stdout io.Writer) int {
+	issues := command.Lint()
+	for _, issue := range issues {
+		fmt.Fprintln(stdout, issue)
+	}
+	if len(issues) > 0 {
+		return exitFailed
+	}
+	return exitOK
+}
+
+func help(command *slashparse.SlashCommand, path []string, format string, stdout, stderr io.Writer) int {
+	if format == "" {
+		format = string(slashparse.HelpFormatMarkdown)
+	}
+
+	commandPath := strings.Join(append([]string{command.Name}, path...), " ")
+	helpText, err := command.GetHelpAs(slashparse.HelpFormat(format), commandPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailed
+	}
+	fmt.Fprint(stdout, helpText)
+	return exitOK
+}
+
+func parse(command *slashparse.SlashCommand, input string, stdout, stderr io.Writer) int {
+	if !strings.HasPrefix(input, "/") {
+		input = "/" + input
+	}
+
+	commandPath, values, err := command.Parse(input)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailed
+	}
+	return writeJSON(stdout, stderr, struct {
+		Path   string            `json:"path"`
+		Values map[string]string `json:"values"`
+	}{commandPath, values})
+}
+
+func export(command *slashparse.SlashCommand, target string, url string, stdout, stderr io.Writer) int {
+	var exported interface{}
+	var issues []slashparse.ExportIssue
+
+	switch target {
+	case "mattermost":
+		exported = command.GetAutocompleteData()
+	case "slack":
+		exported, issues = command.GetSlackCommand(url)
+	case "discord":
+		exported, issues = command.GetDiscordCommand()
+	default:
+		fmt.Fprintf(stderr, "unknown target %q, use mattermost, slack or discord\n", target)
+		return exitUsage
+	}
+
+	for _, issue := range issues {
+		fmt.Fprintln(stderr, "warning:", issue)
+	}
+	return writeJSON(stdout, stderr, exported)
+}
+
+func generate(command *slashparse.SlashCommand, source string, packageName string, out string, stdout, stderr io.Writer) int {
+	if packageName == "" {
+		fmt.Fprintln(stderr, "generate needs --package")
+		return exitUsage
+	}
+
+	code, err := codegen.Generate(command, codegen.Options{Package: packageName, Source: filepath.Base(source)})
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailed
+	}
+
+	if out == "" {
+		stdout.Write(code)
+		return exitOK
+	}
+	if err := ioutil.WriteFile(out, code, 0644); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailed
+	}
+	return exitOK
+}
+
+func writeJSON(stdout, stderr io.Writer, value interface{}) int {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailed
+	}
+	fmt.Fprintln(stdout, string(data))
+	return exitOK
+}
+
+// parseFlags splits args into positional arguments and the values of the named flags, which can come anywhere
+// as --name value or --name=value
+func parseFlags(args []string, names ...string) ([]string, map[string]string, error) {
+	known := make(map[string]bool)
+	for _, name := range names {
+		known[name] = true
+	}
+
+	var positional []string
+	flags := make(map[string]string)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name := strings.TrimPrefix(arg, "--")
+		value := ""
+		if equals := strings.Index(name, "="); equals != -1 {
+			name, value = name[:equals], name[equals+1:]
+		} else if i+1 < len(args) {
+			i++
+			value = args[i]
+		} else {
+			return nil, nil, fmt.Errorf("--%s needs a value", name)
+		}
+
+		if !known[name] {
+			return nil, nil, fmt.Errorf("unknown flag --%s", name)
+		}
+		flags[name] = value
+	}
+	return positional, flags, nil
+}
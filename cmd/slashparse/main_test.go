@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "validate",
+			args:       []string{"validate", "../../testData/todo.yaml"},
+			wantCode:   exitOK,
+			wantStdout: "../../testData/todo.yaml is valid\n",
+		},
+		{
+			name:       "validate missing file",
+			args:       []string{"validate", "missing.yaml"},
+			wantCode:   exitFailed,
+			wantStderr: "open missing.yaml: no such file or directory\n",
+		},
+		{
+			name:       "validate toml",
+			args:       []string{"validate", "../../testData/todo.toml"},
+			wantCode:   exitOK,
+			wantStdout: "../../testData/todo.toml is valid\n",
+		},
+		{
+			name:       "validate unknown format",
+			args:       []string{"validate", "../../testData/todo.txt"},
+			wantCode:   exitFailed,
+			wantStderr: "../../testData/todo.txt is not valid: unable to tell the format of todo.txt, use .yaml, .yml, .json or .toml\n",
+		},
+		{
+			name:     "lint clean",
+			args:     []string{"lint", "../../testData/todo.yaml"},
+			wantCode: exitOK,
+		},
+		{
+			name:       "lint issues",
+			args:       []string{"lint", "../../testData/wrangler.yaml"},
+			wantCode:   exitFailed,
+			wantStdout: "/wrangler list messages: --count has no argtype\n",
+		},
+		{
+			name:       "help",
+			args:       []string{"help", "../../testData/todo.yaml", "list", "--format", "text"},
+			wantCode:   exitOK,
+			wantStdout: "Usage: /todo list [status]\n",
+		},
+		{
+			name:       "help for an unknown command",
+			args:       []string{"help", "../../testData/todo.yaml", "remove"},
+			wantCode:   exitFailed,
+			wantStderr: "/todo remove is not a valid command. Please see /todo help\n",
+		},
+		{
+			name:       "parse",
+			args:       []string{"parse", "../../testData/todo.yaml", "/todo list -s done"},
+			wantCode:   exitOK,
+			wantStdout: "{\n  \"path\": \"todo list\",\n  \"values\": {\n    \"status\": \"done\"\n  }\n}\n",
+		},
+		{
+			name:       "parse error",
+			args:       []string{"parse", "../../testData/todo.yaml", "todo done"},
+			wantCode:   exitFailed,
+			wantStderr: "required field id is missing, see /todo help for more details\n",
+		},
+		{
+			name:       "export slack",
+			args:       []string{"export", "../../testData/todo.yaml", "--target=slack", "--url", "https://example.com/todo"},
+			wantCode:   exitOK,
+			wantStdout: "\"usage_hint\": \"[add|list|done|assign|help]\"",
+		},
+		{
+			name:       "export discord",
+			args:       []string{"export", "../../testData/todo.yaml", "--target", "discord"},
+			wantCode:   exitOK,
+			wantStdout: "\"name\": \"todo\"",
+		},
+		{
+			name:       "export mattermost",
+			args:       []string{"export", "../../testData/todo.yaml", "--target", "mattermost"},
+			wantCode:   exitOK,
+			wantStdout: "\"Trigger\": \"todo\"",
+		},
+		{
+			name:       "unknown target",
+			args:       []string{"export", "../../testData/todo.yaml", "--target", "irc"},
+			wantCode:   exitUsage,
+			wantStderr: "unknown target \"irc\", use mattermost, slack or discord\n",
+		},
+		{
+			name:       "generate",
+			args:       []string{"generate", "../../testData/todo.yaml", "--package", "todo"},
+			wantCode:   exitOK,
+			wantStdout: "// Code generated by slashparse generate from todo.yaml. DO NOT EDIT.\n\npackage todo\n",
+		},
+		{
+			name:       "generate without a package",
+			args:       []string{"generate", "../../testData/todo.yaml"},
+			wantCode:   exitUsage,
+			wantStderr: "generate needs --package\n",
+		},
+		{
+			name:       "unknown flag",
+			args:       []string{"help", "../../testData/todo.yaml", "--color", "red"},
+			wantCode:   exitUsage,
+			wantStderr: "unknown flag --color\n" + usageString,
+		},
+		{
+			name:       "no command",
+			wantCode:   exitUsage,
+			wantStderr: usageString,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			got := run(test.args, &stdout, &stderr)
+			assert.Equal(t, test.wantCode, got)
+			assert.Contains(t, stdout.String(), test.wantStdout)
+			assert.Equal(t, test.wantStderr, stderr.String())
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Command slashrepl is an interactive prompt for trying out a slash command definition.
+//
+//	slashrepl path/to/definition.yaml
+//
+// Every command answers with the path and values it was parsed into.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ericjaystevens/slashparse"
+	"github.com/ericjaystevens/slashparse/repl"
+	"golang.org/x/term"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: slashrepl <definition.yaml|json|toml>")
+		os.Exit(2)
+	}
+
+	command, err := slashparse.LoadSlashCommand(os.Args[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	repl.StubHandlers(&command)
+
+	var input io.Reader = os.Stdin
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer term.Restore(fd, state)
+	} else {
+		input = newlineReader{os.Stdin}
+	}
+
+	if err := repl.New(&command).Run(struct {
+		io.Reader
+		io.Writer
+	}{input, os.Stdout}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+// newlineReader turns the newlines of piped input into the carriage returns a terminal sends for enter
+type newlineReader struct {
+	io.Reader
+}
+
+func (r newlineReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	for i := range p[:n] {
+		if p[i] == '\n' {
+			p[i] = '\r'
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,248 @@
+// Package codegen generates typed handlers for a slash command definition. The generated file has an
+// args struct and a path constant for every command that runs a handler, a Handlers interface with a
+// method for each of them, and a Register function that sets them, so a command renamed in the definition
+// no longer compiles instead of going unhandled.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/ericjaystevens/slashparse"
+)
+
+// Options configure the generated file
+type Options struct {
+	// Package is the package name of the generated file
+	Package string
+	// Source is the definition file named in the header of the generated file
+	Source string
+}
+
+// command is a command that runs a handler, as used by the template
+type command struct {
+	Name        string
+	Path        string
+	Description string
+	Deprecated  string
+	Fields      []field
+}
+
+// field is an argument of a command, as used by the template
+type field struct {
+	Name        string
+	Key         string
+	Type        string
+	Description string
+	Deprecated  string
+}
+
+// Generate returns the gofmt-ed source of the typed handlers of slashCommand
+func Generate(slashCommand *slashparse.SlashCommand, options Options) ([]byte, error) {
+	if options.Package == "" {
+		return nil, fmt.Errorf("a package name is needed to generate code")
+	}
+
+	data := struct {
+		Options
+		CommandName string
+		Commands    []command
+		HasNumbers  bool
+	}{Options: options, CommandName: slashCommand.Name}
+
+	names := make(map[string]string)
+	for _, path := range slashCommand.ExecutablePaths() {
+		definition, ok := findCommand(slashCommand, path)
+		if !ok {
+			continue
+		}
+
+		name := identifier(strings.TrimSpace(path[len(slashCommand.Name):]))
+		if name == "" {
+			name = identifier(slashCommand.Name)
+		}
+		if other, ok := names[name]; ok {
+			return nil, fmt.Errorf("/%s and /%s both generate %s", other, path, name)
+		}
+		names[name] = path
+
+		generated := command{
+			Name:        name,
+			Path:        path,
+			Description: definition.Description,
+			Deprecated:  definition.Deprecated,
+		}
+		for _, arg := range definition.Arguments {
+			argField := field{
+				Name:        identifier(arg.Name),
+				Key:         arg.Name,
+				Type:        "string",
+				Description: arg.Description,
+				Deprecated:  arg.Deprecated,
+			}
+			if arg.ArgType == "number" {
+				argField.Type = "float64"
+				data.HasNumbers = true
+			}
+			generated.Fields = append(generated.Fields, argField)
+		}
+		data.Commands = append(data.Commands, generated)
+	}
+
+	var source bytes.Buffer
+	if err := handlersTemplate.Execute(&source, data); err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code doesn't compile: %s", err)
+	}
+	return formatted, nil
+}
+
+// findCommand finds the slash command or sub command at path
+func findCommand(slashCommand *slashparse.SlashCommand, path string) (slashparse.SubCommand, bool) {
+	if strings.EqualFold(path, slashCommand.Name) {
+		return slashparse.SubCommand{
+			Name:        slashCommand.Name,
+			Description: slashCommand.Description,
+			Deprecated:  slashCommand.Deprecated,
+			Arguments:   slashCommand.Arguments,
+		}, true
+	}
+
+	words := strings.Fields(path)[1:]
+	subCommands := slashCommand.SubCommands
+	var found slashparse.SubCommand
+	for _, word := range words {
+		ok := false
+		for _, subCommand := range subCommands {
+			if subCommand.Name == word {
+				found, subCommands, ok = subCommand, subCommand.SubCommands, true
+				break
+			}
+		}
+		if !ok {
+			return slashparse.SubCommand{}, false
+		}
+	}
+	return found, true
+}
+
+// identifier turns a name or path such as "move thread" or "trim-length" into an exported Go identifier
+func identifier(name string) string {
+	words := strings.FieldsFunc(name, func(character rune) bool {
+		return !unicode.IsLetter(character) && !unicode.IsDigit(character)
+	})
+
+	var id strings.Builder
+	for _, word := range words {
+		if initialisms[strings.ToUpper(word)] {
+			id.WriteString(strings.ToUpper(word))
+			continue
+		}
+		runes := []rune(word)
+		id.WriteRune(unicode.ToUpper(runes[0]))
+		id.WriteString(string(runes[1:]))
+	}
+
+	result := id.String()
+	if result != "" && unicode.IsDigit(rune(result[0])) {
+		result = "N" + result
+	}
+	return result
+}
+
+// initialisms are kept upper case, as golint expects
+var initialisms = map[string]bool{
+	"API":  true,
+	"HTML": true,
+	"HTTP": true,
+	"ID":   true,
+	"JSON": true,
+	"URL":  true,
+}
+
+// comment makes text safe to use in a line comment
+func comment(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+var handlersTemplate = template.Must(template.New("handlers").Funcs(template.FuncMap{
+	"comment": comment,
+}).Parse(`// Code generated by slashparse generate{{if .Source}} from {{.Source}}{{end}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+{{- if .HasNumbers}}
+	"fmt"
+	"strconv"
+{{- end}}
+
+	"github.com/ericjaystevens/slashparse"
+)
+
+// Command paths of /{{.CommandName}} that run a handler
+const (
+{{- range .Commands}}
+	// Path{{.Name}} is /{{.Path}}
+	Path{{.Name}} = {{printf "%q" .Path}}
+{{- end}}
+)
+{{range .Commands}}
+// {{.Name}}Args are the arguments of /{{.Path}}
+type {{.Name}}Args struct {
+{{- range .Fields}}
+	// {{.Name}} is the {{.Key}} argument{{if .Description}}: {{comment .Description}}{{end}}
+{{- if .Deprecated}}
+	//
+	// Deprecated: {{comment .Deprecated}}
+{{- end}}
+	{{.Name}} {{.Type}}
+{{- end}}
+}
+{{end}}
+// Handlers handles every command of /{{.CommandName}} that runs a handler
+type Handlers interface {
+{{- range .Commands}}
+	// {{.Name}} handles /{{.Path}}{{if .Description}}: {{comment .Description}}{{end}}
+{{- if .Deprecated}}
+	//
+	// Deprecated: {{comment .Deprecated}}
+{{- end}}
+	{{.Name}}(ctx context.Context, req slashparse.Request, args {{.Name}}Args) (slashparse.Response, error)
+{{- end}}
+}
+
+// Register sets the methods of handlers as the handlers of /{{.CommandName}}
+func Register(command *slashparse.SlashCommand, handlers Handlers) error {
+{{- range .Commands}}
+	if err := command.SetContextHandler(Path{{.Name}}, func(ctx context.Context, req slashparse.Request, values map[string]string) (slashparse.Response, error) {
+		var args {{.Name}}Args
+{{- range .Fields}}
+{{- if eq .Type "float64"}}
+		if value := values[{{printf "%q" .Key}}]; value != "" {
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return slashparse.Response{}, fmt.Errorf("--{{.Key}} %s is not a number", value)
+			}
+			args.{{.Name}} = number
+		}
+{{- else}}
+		args.{{.Name}} = values[{{printf "%q" .Key}}]
+{{- end}}
+{{- end}}
+		return handlers.{{.Name}}(ctx, req, args)
+	}); err != nil {
+		return err
+	}
+{{- end}}
+	return nil
+}
+`))
@@ -0,0 +1,79 @@
+package codegen
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/ericjaystevens/slashparse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	def, _ := ioutil.ReadFile("../examples/todo/todo.yaml")
+	command, _ := slashparse.NewSlashCommand(def)
+	want, _ := ioutil.ReadFile("../examples/todo/handlers.go")
+
+	got, err := Generate(&command, Options{Package: "todo", Source: "todo.yaml"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, string(want), string(got), "examples/todo/handlers.go is out of date, run go generate ./examples/todo")
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     string
+		options Options
+		wantErr string
+	}{
+		{
+			name:    "no package",
+			def:     "name: print\ndescription: Prints things\n",
+			wantErr: "a package name is needed to generate code",
+		},
+		{
+			name: "colliding identifiers",
+			def: `name: todo
+description: Keep track of things to do
+subcommands:
+  - name: add-item
+    description: Add a todo
+  - name: add_item
+    description: Add a todo
+`,
+			options: Options{Package: "todo"},
+			wantErr: "/todo add-item and /todo add_item both generate AddItem",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			command, err := slashparse.NewSlashCommand([]byte(test.def))
+			assert.Nil(t, err)
+
+			_, err = Generate(&command, test.options)
+			assert.EqualError(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"print", "Print"},
+		{"move thread", "MoveThread"},
+		{"trim-length", "TrimLength"},
+		{"messageID", "MessageID"},
+		{"id", "ID"},
+		{"channel_url", "ChannelURL"},
+		{"2fa", "N2fa"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, identifier(test.name))
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package slashparse
+
+import (
+	"errors"
+	"strings"
+)
+
+// Completion is a candidate for the token under the cursor
+type Completion struct {
+	// Value replaces the token under the cursor, such as "thread", "--channelID" or a choice
+	Value string `json:"value"`
+	// Description explains the candidate, empty when there is none
+	Description string `json:"description,omitempty"`
+}
+
+// CompletionProvider returns the values an argument can take when they can't be listed as choices in the
+// definition, such as IDs from a database. prefix is what has been typed of the value so far.
+type CompletionProvider func(prefix string) ([]Completion, error)
+
+// SetCompletionProvider registers the provider of values for an argument of the command at commandString
+func (s *SlashCommand) SetCompletionProvider(commandString string, argumentName string, provider CompletionProvider) error {
+	if provider == nil {
+		return errors.New("a completion provider needs a function")
+	}
+
+	command, ok := s.findCommand(commandString)
+	if !ok {
+		return errors.New("Unable to find mathing subcommand")
+	}
+	if _, ok := findArgument(command.Arguments, argumentName); !ok {
+		return errors.New("/" + commandString + " has no argument " + argumentName)
+	}
+
+	if s.completions == nil {
+		s.completions = make(map[string]CompletionProvider)
+	}
+	s.completions[completionKey(command.getCommandPath(), argumentName)] = provider
+	return nil
+}
+
+// Complete returns the candidates for the token under the cursor, a byte offset into input. Candidates are the
+// sub commands, the flags not used yet, and the choices or provided values of the argument being typed.
+func (s *SlashCommand) Complete(input string, cursor int) ([]Completion, error) {
+	if cursor < 0 || cursor > len(input) {
+		cursor = len(input)
+	}
+	text := input[:cursor]
+
+	tokens := GetPositionalArgs(text)
+	prefix := ""
+	if len(tokens) > 0 && (inQuotes(text) || !strings.HasSuffix(text, " ")) {
+		prefix = tokens[len(tokens)-1]
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	if len(tokens) == 0 {
+		if hasPrefixFold("/"+s.Name, prefix) || hasPrefixFold(s.Name, prefix) {
+			return []Completion{{Value: "/" + s.Name, Description: s.Description}}, nil
+		}
+		return nil, nil
+	}
+	if !strings.EqualFold(strings.TrimPrefix(tokens[0], "/"), s.Name) {
+		return nil, nil
+	}
+
+	command := s.asSubCommand()
+	argTokens := tokens[1:]
+	for len(argTokens) > 0 {
+		subCommand, ok := findCompletableSubCommand(command.SubCommands, argTokens[0])
+		if !ok {
+			break
+		}
+		command = subCommand
+		argTokens = argTokens[1:]
+	}
+
+	//work out which arguments are used, and whether the last token is a flag waiting for its value
+	used := make(map[string]bool)
+	var pending *Argument
+	positional := 0
+	flagSeen := false
+	for _, token := range argTokens {
+		if pending != nil {
+			pending = nil
+			continue
+		}
+		if strings.HasPrefix(token, "-") {
+			flagSeen = true
+			if arg, ok := findFlag(command.Arguments, token); ok {
+				used[arg.Name] = true
+				pending = &arg
+			}
+			continue
+		}
+		if !flagSeen {
+			if arg, ok := findPosition(command.Arguments, positional); ok {
+				used[arg.Name] = true
+			}
+			positional++
+		}
+	}
+
+	if pending != nil {
+		return s.completeValue(command, *pending, prefix)
+	}
+
+	var completions []Completion
+	if strings.HasPrefix(prefix, "-") {
+		return completeFlags(command.Arguments, used, prefix), nil
+	}
+
+	if len(argTokens) == 0 {
+		for _, subCommand := range command.SubCommands {
+			if !subCommand.Hidden && hasPrefixFold(subCommand.Name, prefix) {
+				completions = append(completions, Completion{Value: subCommand.Name, Description: subCommand.Description})
+			}
+		}
+	}
+
+	if !flagSeen {
+		if arg, ok := findPosition(command.Arguments, positional); ok && !arg.Hidden {
+			values, err := s.completeValue(command, arg, prefix)
+			if err != nil {
+				return nil, err
+			}
+			completions = append(completions, values...)
+		}
+	}
+
+	if prefix == "" {
+		completions = append(completions, completeFlags(command.Arguments, used, prefix)...)
+	}
+	return completions, nil
+}
+
+// completeValue returns the choices of an argument, or the values of its provider, that start with prefix
+func (s *SlashCommand) completeValue(command SubCommand, arg Argument, prefix string) ([]Completion, error) {
+	var candidates []Completion
+	if len(arg.Choices) > 0 {
+		for _, choice := range arg.Choices {
+			candidates = append(candidates, Completion{Value: choice.Value, Description: choice.Description})
+		}
+	} else if provider, ok := s.completions[completionKey(command.getCommandPath(), arg.Name)]; ok {
+		provided, err := provider(prefix)
+		if err != nil {
+			return nil, err
+		}
+		candidates = provided
+	}
+
+	var completions []Completion
+	for _, candidate := range candidates {
+		if hasPrefixFold(candidate.Value, prefix) {
+			completions = append(completions, candidate)
+		}
+	}
+	return completions, nil
+}
+
+// completeFlags returns the long and short flags of the arguments not used yet that start with prefix
+func completeFlags(arguments []Argument, used map[string]bool, prefix string) []Completion {
+	var completions []Completion
+	for _, arg := range visibleArguments(arguments) {
+		if used[arg.Name] {
+			continue
+		}
+		if hasPrefixFold("--"+arg.Name, prefix) {
+			completions = append(completions, Completion{Value: "--" + arg.Name, Description: arg.Description})
+		}
+		if arg.ShortName != "" && strings.HasPrefix("-"+arg.ShortName, prefix) {
+			completions = append(completions, Completion{Value: "-" + arg.ShortName, Description: arg.Description})
+		}
+	}
+	return completions
+}
+
+// findCommand finds the slash command or sub command at commandString
+func (s *SlashCommand) findCommand(commandString string) (SubCommand, bool) {
+	if strings.EqualFold(commandString, s.Name) {
+		return s.asSubCommand(), true
+	}
+	subCommand, err := s.getSubCommand(commandString)
+	return subCommand, err == nil
+}
+
+// findCompletableSubCommand finds a sub command by name, including the built-in help
+func findCompletableSubCommand(subCommands []SubCommand, name string) (SubCommand, bool) {
+	for _, subCommand := range subCommands {
+		if strings.EqualFold(subCommand.Name, name) {
+			return subCommand, true
+		}
+	}
+	return SubCommand{}, false
+}
+
+func findArgument(arguments []Argument, name string) (Argument, bool) {
+	for _, arg := range arguments {
+		if arg.Name == name {
+			return arg, true
+		}
+	}
+	return Argument{}, false
+}
+
+// findFlag finds the argument a --name or -shortName token refers to
+func findFlag(arguments []Argument, token string) (Argument, bool) {
+	for _, arg := range arguments {
+		if token == "--"+arg.Name || (arg.ShortName != "" && token == "-"+arg.ShortName) {
+			return arg, true
+		}
+	}
+	return Argument{}, false
+}
+
+// findPosition finds the argument a positional value goes to, remaining text takes every value after its position
+func findPosition(arguments []Argument, position int) (Argument, bool) {
+	for _, arg := range arguments {
+		if arg.Position == position || (arg.ArgType == "remaining text" && arg.Position < position) {
+			return arg, true
+		}
+	}
+	return Argument{}, false
+}
+
+func completionKey(commandPath string, argumentName string) string {
+	return strings.ToLower(commandPath) + " --" + argumentName
+}
+
+// inQuotes returns true when text ends inside an unclosed double quote
+func inQuotes(text string) bool {
+	quoted := false
+	var previous rune
+	for _, character := range text {
+		if character == doubleQuote && previous != backspace {
+			quoted = !quoted
+		}
+		previous = character
+	}
+	return quoted
+}
+
+func hasPrefixFold(s string, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
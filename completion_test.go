@@ -0,0 +1,117 @@
+package slashparse
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Completion
+	}{
+		{
+			name:  "slash command",
+			input: "/to",
+			want:  []Completion{{Value: "/todo", Description: "Keep track of things to do"}},
+		},
+		{
+			name:  "sub commands",
+			input: "/todo a",
+			want: []Completion{
+				{Value: "add", Description: "Add a todo"},
+				{Value: "assign", Description: "Assign a todo to someone"},
+			},
+		},
+		{
+			name:  "choices",
+			input: "/todo list ",
+			want: []Completion{
+				{Value: "open", Description: "Todos that still need doing"},
+				{Value: "done", Description: "Todos that are finished"},
+				{Value: "all"},
+				{Value: "--status", Description: "Which todos to list"},
+				{Value: "-s", Description: "Which todos to list"},
+			},
+		},
+		{
+			name:  "choices of a flag",
+			input: "/todo list --status d",
+			want:  []Completion{{Value: "done", Description: "Todos that are finished"}},
+		},
+		{
+			name:  "choices of a short flag",
+			input: "/todo list -s ",
+			want: []Completion{
+				{Value: "open", Description: "Todos that still need doing"},
+				{Value: "done", Description: "Todos that are finished"},
+				{Value: "all"},
+			},
+		},
+		{
+			name:  "flags",
+			input: "/todo assign 12 -",
+			want:  []Completion{{Value: "--user", Description: "Who should do it"}},
+		},
+		{
+			name:  "used flags are left out",
+			input: "/todo list --status open -",
+		},
+		{
+			name:  "unknown command",
+			input: "/wrangler m",
+		},
+	}
+
+	newSlash, _ := NewSlashCommand(todoDef)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := newSlash.Complete(test.input, len(test.input))
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestCompleteCursor(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+
+	input := "/wrangler mo thread 1234"
+	got, _ := newSlash.Complete(input, len("/wrangler mo"))
+	assert.Equal(t, []Completion{{Value: "move", Description: "Move a message"}}, got)
+
+	got, _ = newSlash.Complete("/wrangler move thread 1234 -", -1)
+	assert.Equal(t, []Completion{
+		{Value: "--channelID", Description: "The ID of the channel where the message will be moved to"},
+	}, got)
+}
+
+func TestCompletionProvider(t *testing.T) {
+	newSlash, _ := NewSlashCommand(todoDef)
+
+	err := newSlash.SetCompletionProvider("todo assign", "user", func(prefix string) ([]Completion, error) {
+		return []Completion{{Value: "alice"}, {Value: "bob"}, {Value: "anne"}}, nil
+	})
+	assert.NoError(t, err)
+
+	got, err := newSlash.Complete("/todo assign 12 a", 17)
+	assert.NoError(t, err)
+	assert.Equal(t, []Completion{{Value: "alice"}, {Value: "anne"}}, got)
+
+	got, _ = newSlash.Complete("/todo assign 12 --user b", 24)
+	assert.Equal(t, []Completion{{Value: "bob"}}, got)
+
+	err = newSlash.SetCompletionProvider("todo done", "id", func(prefix string) ([]Completion, error) {
+		return nil, errors.New("database is down")
+	})
+	assert.NoError(t, err)
+	_, err = newSlash.Complete("/todo done ", 11)
+	assert.EqualError(t, err, "database is down")
+
+	assert.Error(t, newSlash.SetCompletionProvider("todo remove", "id", func(string) ([]Completion, error) { return nil, nil }))
+	assert.Error(t, newSlash.SetCompletionProvider("todo done", "user", func(string) ([]Completion, error) { return nil, nil }))
+	assert.Error(t, newSlash.SetCompletionProvider("todo done", "id", nil))
+}
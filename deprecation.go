@@ -0,0 +1,63 @@
+package slashparse
+
+import (
+	"strings"
+)
+
+// getDeprecationWarnings returns a warning for each deprecated command along the command path,
+// and for each deprecated argument used in splitArgs, the tokens after the command path. quoted reports which
+// tokens were quoted, those are values and never name an argument.
+func (s *SlashCommand) getDeprecationWarnings(commandString string, splitArgs []string, quoted []bool) []string {
+	var warnings []string
+
+	chain := append([]SubCommand{s.asSubCommand()}, s.getSubCommandChain(commandString)...)
+	for _, command := range chain {
+		if command.Deprecated != "" {
+			warnings = append(warnings, deprecationWarning("/"+strings.ToLower(command.getCommandPath()), command.Deprecated))
+		}
+	}
+
+	for _, arg := range chain[len(chain)-1].Arguments {
+		if arg.Deprecated != "" && isArgumentUsed(arg, splitArgs, quoted) {
+			warnings = append(warnings, deprecationWarning("--"+arg.Name, arg.Deprecated))
+		}
+	}
+	return warnings
+}
+
+func deprecationWarning(name string, message string) string {
+	return "Warning: " + name + " is deprecated. " + message
+}
+
+// isArgumentUsed reports if an argument was passed by name, short name or position.
+// Like getArgsValues, positional values stop at the first named argument.
+func isArgumentUsed(arg Argument, splitArgs []string, quoted []bool) bool {
+	positionalCount := 0
+	inNamedArgs := false
+	for i, splitArg := range splitArgs {
+		if !isFlag(splitArgs, quoted, i) {
+			if !inNamedArgs {
+				positionalCount++
+			}
+			continue
+		}
+		if splitArg == "--"+arg.Name || (arg.ShortName != "" && splitArg == "-"+arg.ShortName) {
+			return true
+		}
+		inNamedArgs = true
+	}
+	return arg.Position < positionalCount
+}
+
+// appendWarnings adds warnings after the response text
+func appendWarnings(response Response, warnings []string) Response {
+	if len(warnings) == 0 {
+		return response
+	}
+
+	if response.Text != "" {
+		response.Text += "\n\n"
+	}
+	response.Text += strings.Join(warnings, "\n")
+	return response
+}
@@ -0,0 +1,90 @@
+package slashparse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type deprecationTests struct {
+	name          string
+	commandString string
+	want          string
+}
+
+func TestDeprecationWarnings(t *testing.T) {
+	tests := []deprecationTests{
+		{
+			name:          "deprecated command still runs",
+			commandString: "/wrangler attach message abc def",
+			want:          "attached abc\n\nWarning: /wrangler attach message is deprecated. Use /wrangler move thread instead.",
+		},
+		{
+			name:          "deprecated argument by name",
+			commandString: "/wrangler list messages --trim 10",
+			want:          "listed\n\nWarning: --trim is deprecated. Use --trim-length instead.",
+		},
+		{
+			name:          "deprecated argument by position",
+			commandString: "/wrangler list messages 20 50 no 10",
+			want:          "listed\n\nWarning: --trim is deprecated. Use --trim-length instead.",
+		},
+		{
+			name:          "deprecated argument not used",
+			commandString: "/wrangler list messages 20 --trim-length 10",
+			want:          "listed",
+		},
+		{
+			name:          "quoted flag is a value",
+			commandString: `/wrangler list messages "--trim" yes`,
+			want:          "listed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newSlash, _ := NewSlashCommand(wranglerDef)
+			_ = newSlash.SetHandler("wrangler attach message", func(values map[string]string) (string, error) {
+				return "attached " + values["messageID"], nil
+			})
+			_ = newSlash.SetHandler("wrangler list messages", func(values map[string]string) (string, error) {
+				return "listed", nil
+			})
+
+			got, err := newSlash.Execute(test.commandString)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestHiddenAndDeprecatedHelp(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+
+	help, _ := newSlash.GetHelp("wrangler attach")
+	assert.True(t, strings.Contains(help, "* **message**: _Attach messages_ **(deprecated: Use /wrangler move thread instead.)**"), help)
+
+	help, _ = newSlash.GetHelp("wrangler list messages")
+	assert.False(t, strings.Contains(help, "raw"), help)
+	assert.True(t, strings.Contains(help, "**(deprecated: Use --trim-length instead.)**"), help)
+
+	model, _ := newSlash.GetHelpModel("wrangler list messages")
+	assert.Equal(t, "/wrangler list messages [count] [trim-length] [trim]", model.Usage)
+}
+
+func TestHiddenCommandsStillRun(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+	newSlash.SubCommands[0].Hidden = true
+	_ = newSlash.SetHandler("wrangler info", func(values map[string]string) (string, error) {
+		return "info", nil
+	})
+
+	help := newSlash.GetSlashHelp()
+	got, err := newSlash.Execute("/wrangler info")
+
+	assert.False(t, strings.Contains(help, "**info**"))
+	assert.Nil(t, err)
+	assert.Equal(t, "info", got)
+}
@@ -0,0 +1,370 @@
+// Package discord serves a slashparse SlashCommand as the interactions endpoint of a Discord application.
+// Register the command with the json from SlashCommand.GetDiscordCommand.
+package discord
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ericjaystevens/slashparse"
+)
+
+// DefaultAPIURL is the Discord API follow-up messages are sent to
+const DefaultAPIURL = "https://discord.com/api/v10"
+
+// DefaultTimeout is how long a command can run before the reply is deferred,
+// Discord gives up on an interaction after three seconds
+const DefaultTimeout = 2 * time.Second
+
+// maxBodySize is the largest request body read, interactions are far smaller
+const maxBodySize = 1 << 20
+
+// ErrInvalidSignature is returned when a request is not signed with the application's key
+var ErrInvalidSignature = errors.New("invalid discord request signature")
+
+// Handler is an http.Handler for the interactions endpoint of a Discord application
+type Handler struct {
+	// Command is the slash command interactions are executed with
+	Command *slashparse.SlashCommand
+	// PublicKey is the public key of the Discord application
+	PublicKey ed25519.PublicKey
+	// Timeout is how long a command can run before the reply is deferred and the result sent as
+	// a follow-up, DefaultTimeout when zero. Deferred replies are visible to the whole channel.
+	Timeout time.Duration
+	// APIURL is where follow-ups are sent, DefaultAPIURL when empty
+	APIURL string
+	// Client sends follow-ups, http.DefaultClient when nil
+	Client *http.Client
+	// ErrorLog is called with errors that can't be returned to Discord, such as failed follow-ups. It may be nil.
+	ErrorLog func(err error)
+}
+
+// NewHandler creates a Handler for the application with the hex encoded publicKey from its General Information page
+func NewHandler(command *slashparse.SlashCommand, publicKey string) (*Handler, error) {
+	key, err := hex.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("the discord public key must be 64 hex characters")
+	}
+	return &Handler{
+		Command:   command,
+		PublicKey: ed25519.PublicKey(key),
+	}, nil
+}
+
+// ServeHTTP verifies the interaction, answers pings and executes application commands
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "unable to read request", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Verify(r.Header, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var interaction Interaction
+	if err := json.Unmarshal(body, &interaction); err != nil {
+		http.Error(w, "unable to parse interaction", http.StatusBadRequest)
+		return
+	}
+
+	switch interaction.Type {
+	case InteractionTypePing:
+		writeJSON(w, InteractionResponse{Type: ResponseTypePong})
+	case InteractionTypeApplicationCommand:
+		writeJSON(w, h.execute(interaction))
+	default:
+		http.Error(w, "unsupported interaction type", http.StatusBadRequest)
+	}
+}
+
+// Verify checks the X-Signature-Ed25519 header is the signature of the X-Signature-Timestamp header and body
+func (h *Handler) Verify(header http.Header, body []byte) error {
+	signature, err := hex.DecodeString(header.Get("X-Signature-Ed25519"))
+	if err != nil || len(signature) != ed25519.SignatureSize || len(h.PublicKey) != ed25519.PublicKeySize {
+		return ErrInvalidSignature
+	}
+
+	message := append([]byte(header.Get("X-Signature-Timestamp")), body...)
+	if !ed25519.Verify(h.PublicKey, message, signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// execute runs the command, deferring the reply when it takes longer than the timeout
+func (h *Handler) execute(interaction Interaction) InteractionResponse {
+	req, err := NewRequest(h.Command, interaction)
+	if err != nil {
+		return NewInteractionResponse(slashparse.Response{Text: err.Error(), Visibility: slashparse.VisibilityEphemeral})
+	}
+
+	timeout := h.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	//the http request is done once the reply is deferred, so the command doesn't run with its context
+	done := make(chan slashparse.Response, 1)
+	go func() {
+		response, err := h.Command.ExecuteContext(context.Background(), req)
+		if err != nil && response.Text == "" {
+			response = slashparse.Response{Text: err.Error(), Visibility: slashparse.VisibilityEphemeral}
+		}
+		done <- response
+	}()
+
+	select {
+	case response := <-done:
+		return NewInteractionResponse(response)
+	case <-time.After(timeout):
+		go func() {
+			if err := h.sendFollowUp(interaction, <-done); err != nil && h.ErrorLog != nil {
+				h.ErrorLog(err)
+			}
+		}()
+		return InteractionResponse{Type: ResponseTypeDeferredChannelMessageWithSource}
+	}
+}
+
+// sendFollowUp replaces the deferred reply with the response
+func (h *Handler) sendFollowUp(interaction Interaction, response slashparse.Response) error {
+	apiURL := h.APIURL
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	body, err := json.Marshal(NewInteractionResponse(response).Data)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/webhooks/%s/%s/messages/@original", strings.TrimSuffix(apiURL, "/"), interaction.ApplicationID, interaction.Token)
+	followUp, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	followUp.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(followUp)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("discord follow-up failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// NewRequest converts an application command interaction into a slashparse request. The options are turned back
+// into a command path and named arguments, matching the lower cased Discord names to the definition.
+func NewRequest(command *slashparse.SlashCommand, interaction Interaction) (slashparse.Request, error) {
+	if !strings.EqualFold(interaction.Data.Name, command.Name) {
+		return slashparse.Request{}, fmt.Errorf("/%s is not a valid command", interaction.Data.Name)
+	}
+
+	text := "/" + command.Name
+	subCommands := command.SubCommands
+	arguments := command.Arguments
+	isHelp := false
+	options := interaction.Data.Options
+
+	for len(options) == 1 && isSubCommandOption(options[0]) {
+		subCommand, ok := findSubCommand(subCommands, options[0].Name)
+		if !ok {
+			return slashparse.Request{}, fmt.Errorf("%s is not a valid command. Please see /%s help", options[0].Name, command.Name)
+		}
+		//the built-in help takes the command path as it is typed, not as a named argument
+		isHelp = text == "/"+command.Name && subCommand.Name == "help"
+		text += " " + subCommand.Name
+		subCommands = subCommand.SubCommands
+		arguments = subCommand.Arguments
+		options = options[0].Options
+	}
+
+	for _, option := range options {
+		value := optionValue(option.Value)
+		if isHelp {
+			text += " " + value
+			continue
+		}
+		name := option.Name
+		for _, arg := range arguments {
+			if strings.EqualFold(arg.Name, option.Name) {
+				name = arg.Name
+			}
+		}
+		text += " --" + name + " " + quote(value)
+	}
+
+	req := slashparse.Request{
+		ChannelID: interaction.ChannelID,
+		TeamID:    interaction.GuildID,
+		Platform:  "discord",
+		Text:      text,
+		Metadata: map[string]string{
+			"interactionID":    interaction.ID,
+			"applicationID":    interaction.ApplicationID,
+			"interactionToken": interaction.Token,
+		},
+	}
+
+	user := interaction.User
+	if interaction.Member != nil {
+		user = &interaction.Member.User
+	}
+	if user != nil {
+		req.UserID = user.ID
+		req.Metadata["userName"] = user.Username
+	}
+	return req, nil
+}
+
+// NewInteractionResponse converts a response into a reply to the interaction. Attachments become embeds and
+// actions become buttons; the follow-up URL and metadata have no place in a Discord message and are left out.
+func NewInteractionResponse(response slashparse.Response) InteractionResponse {
+	data := &MessageData{Content: response.Text}
+	if response.Visibility == slashparse.VisibilityEphemeral {
+		data.Flags = flagEphemeral
+	}
+
+	for _, attachment := range response.Attachments {
+		embed := Embed{
+			Title:       attachment.Title,
+			URL:         attachment.TitleLink,
+			Description: attachment.Text,
+			Color:       parseColor(attachment.Color),
+		}
+		if attachment.ImageURL != "" {
+			embed.Image = &EmbedImage{URL: attachment.ImageURL}
+		}
+		for _, field := range attachment.Fields {
+			embed.Fields = append(embed.Fields, EmbedField{Name: field.Title, Value: field.Value, Inline: field.Short})
+		}
+		data.Embeds = append(data.Embeds, embed)
+	}
+
+	//an action row holds at most five buttons
+	for i, action := range response.Actions {
+		if i%5 == 0 {
+			data.Components = append(data.Components, Component{Type: componentTypeActionRow})
+		}
+		row := &data.Components[len(data.Components)-1]
+		row.Components = append(row.Components, newButton(action))
+	}
+
+	return InteractionResponse{Type: ResponseTypeChannelMessageWithSource, Data: data}
+}
+
+// component types and button styles
+const (
+	componentTypeActionRow = 1
+	componentTypeButton    = 2
+
+	buttonStylePrimary   = 1
+	buttonStyleSecondary = 2
+	buttonStyleSuccess   = 3
+	buttonStyleDanger    = 4
+	buttonStyleLink      = 5
+)
+
+// newButton converts an action into a button. Buttons with a URL are links, others send the action ID
+// and value back as their custom_id.
+func newButton(action slashparse.Action) Component {
+	button := Component{Type: componentTypeButton, Label: action.Text}
+	if action.URL != "" {
+		button.Style = buttonStyleLink
+		button.URL = action.URL
+		return button
+	}
+
+	button.CustomID = action.ID
+	if action.Value != "" {
+		button.CustomID += ":" + action.Value
+	}
+	switch strings.ToLower(action.Style) {
+	case "primary":
+		button.Style = buttonStylePrimary
+	case "good", "success":
+		button.Style = buttonStyleSuccess
+	case "danger":
+		button.Style = buttonStyleDanger
+	default:
+		button.Style = buttonStyleSecondary
+	}
+	return button
+}
+
+func isSubCommandOption(option InteractionOption) bool {
+	return option.Type == int(slashparse.DiscordOptionSubCommand) || option.Type == int(slashparse.DiscordOptionSubCommandGroup)
+}
+
+func findSubCommand(subCommands []slashparse.SubCommand, name string) (slashparse.SubCommand, bool) {
+	for _, subCommand := range subCommands {
+		if strings.EqualFold(subCommand.Name, name) {
+			return subCommand, true
+		}
+	}
+	return slashparse.SubCommand{}, false
+}
+
+// optionValue formats the value of an option the way it would be typed
+func optionValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return fmt.Sprint(value)
+}
+
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// quote wraps values in double quotes when they have spaces or quotes, so they parse as one argument, or start
+// with a dash, so they aren't taken for a flag such as --help. Backslashes and quotes inside the value are escaped.
+func quote(value string) string {
+	if value == "" || strings.ContainsAny(value, " \"") || strings.HasPrefix(value, "-") {
+		return `"` + quoteEscaper.Replace(value) + `"`
+	}
+	return value
+}
+
+// parseColor converts a #rrggbb color into the integer Discord expects, 0 when it can't
+func parseColor(color string) int {
+	value, err := strconv.ParseInt(strings.TrimPrefix(color, "#"), 16, 32)
+	if err != nil {
+		return 0
+	}
+	return int(value)
+}
+
+func writeJSON(w http.ResponseWriter, response InteractionResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
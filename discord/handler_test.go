@@ -0,0 +1,262 @@
+package discord
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ericjaystevens/slashparse"
+	"github.com/stretchr/testify/assert"
+)
+
+var privateKey = ed25519.NewKeyFromSeed([]byte("slashparse discord test seed 32b"))
+
+func newTestCommand(t *testing.T, path string) *slashparse.SlashCommand {
+	def, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	command, err := slashparse.NewSlashCommand(def)
+	assert.NoError(t, err)
+	return &command
+}
+
+func newTestHandler(t *testing.T, command *slashparse.SlashCommand) *Handler {
+	handler, err := NewHandler(command, hex.EncodeToString(privateKey.Public().(ed25519.PublicKey)))
+	assert.NoError(t, err)
+	return handler
+}
+
+func newSignedRequest(body string, key ed25519.PrivateKey) *http.Request {
+	timestamp := "1625603592"
+	req := httptest.NewRequest(http.MethodPost, "/interactions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Signature-Timestamp", timestamp)
+	req.Header.Set("X-Signature-Ed25519", hex.EncodeToString(ed25519.Sign(key, []byte(timestamp+body))))
+	return req
+}
+
+const pingPayload = `{"application_id":"857694315245158410","id":"861996112284614666","token":"aW50ZXJhY3Rpb246ODYx","type":1,"user":{"id":"53908232506183680","username":"mason"},"version":1}`
+
+const movePayload = `{
+	"application_id": "857694315245158410",
+	"channel_id": "645027906669510667",
+	"data": {
+		"id": "866818195033292850",
+		"name": "wrangler",
+		"options": [{
+			"name": "move",
+			"type": 2,
+			"options": [{
+				"name": "thread",
+				"type": 1,
+				"options": [
+					{"name": "messageid", "type": 3, "value": "8ehqpbrjw3f5m"},
+					{"name": "channelid", "type": 3, "value": "x9ca kaz"}
+				]
+			}]
+		}],
+		"type": 1
+	},
+	"guild_id": "613425648685547541",
+	"id": "866818200880218112",
+	"member": {"roles": ["617509521093763120"], "user": {"id": "53908232506183680", "username": "mason"}},
+	"token": "aW50ZXJhY3Rpb246ODY2",
+	"type": 2,
+	"version": 1
+}`
+
+func TestServeHTTPPing(t *testing.T) {
+	handler := newTestHandler(t, newTestCommand(t, "../testData/todo.yaml"))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newSignedRequest(pingPayload, privateKey))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"type":1}`+"\n", rec.Body.String())
+
+	_, otherKey, _ := ed25519.GenerateKey(nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newSignedRequest(pingPayload, otherKey))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "invalid discord request signature\n", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/interactions", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestServeHTTPApplicationCommand(t *testing.T) {
+	command := newTestCommand(t, "../testData/wrangler.yaml")
+	command.SetContextHandler("wrangler move thread", func(ctx context.Context, req slashparse.Request, values map[string]string) (slashparse.Response, error) {
+		return slashparse.Response{
+			Text:       req.UserID + " moved " + values["messageID"] + " to " + values["channelID"] + " in " + req.TeamID,
+			Visibility: slashparse.VisibilityInChannel,
+		}, nil
+	})
+
+	rec := httptest.NewRecorder()
+	newTestHandler(t, command).ServeHTTP(rec, newSignedRequest(movePayload, privateKey))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"type":4,"data":{"content":"53908232506183680 moved 8ehqpbrjw3f5m to x9ca kaz in 613425648685547541"}}`+"\n", rec.Body.String())
+}
+
+func TestNewRequest(t *testing.T) {
+	command := newTestCommand(t, "../testData/todo.yaml")
+
+	tests := []struct {
+		name     string
+		data     ApplicationCommandData
+		wantText string
+		wantErr  string
+	}{
+		{
+			name: "choice",
+			data: ApplicationCommandData{Name: "todo", Options: []InteractionOption{
+				{Name: "list", Type: 1, Options: []InteractionOption{{Name: "status", Type: 3, Value: "done"}}},
+			}},
+			wantText: "/todo list --status done",
+		},
+		{
+			name: "number",
+			data: ApplicationCommandData{Name: "todo", Options: []InteractionOption{
+				{Name: "done", Type: 1, Options: []InteractionOption{{Name: "id", Type: 10, Value: float64(12)}}},
+			}},
+			wantText: "/todo done --id 12",
+		},
+		{
+			name: "help",
+			data: ApplicationCommandData{Name: "todo", Options: []InteractionOption{
+				{Name: "help", Type: 1, Options: []InteractionOption{{Name: "command", Type: 3, Value: "list"}}},
+			}},
+			wantText: "/todo help list",
+		},
+		{
+			name:    "unknown sub command",
+			data:    ApplicationCommandData{Name: "todo", Options: []InteractionOption{{Name: "remove", Type: 1}}},
+			wantErr: "remove is not a valid command. Please see /todo help",
+		},
+		{
+			name:    "other command",
+			data:    ApplicationCommandData{Name: "wrangler"},
+			wantErr: "/wrangler is not a valid command",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NewRequest(command, Interaction{Type: 2, Data: test.data, User: &User{ID: "1", Username: "mason"}})
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantText, got.Text)
+			assert.Equal(t, "1", got.UserID)
+			assert.Equal(t, "discord", got.Platform)
+		})
+	}
+}
+
+func TestNewRequestValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		wantText string
+	}{
+		{name: "plain", value: "milk", wantText: "/todo add --message milk"},
+		{name: "spaces", value: "buy milk", wantText: `/todo add --message "buy milk"`},
+		{name: "quotes", value: `say "hi" to "them"`, wantText: `/todo add --message "say \"hi\" to \"them\""`},
+		{name: "help flag", value: "--help", wantText: `/todo add --message "--help"`},
+		{name: "dash", value: "-s done", wantText: `/todo add --message "-s done"`},
+		{name: "trailing backslash", value: `C:\new folder\`, wantText: `/todo add --message "C:\\new folder\\"`},
+		{name: "escaped quote", value: `say \"hi`, wantText: `/todo add --message "say \\\"hi"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			command := newTestCommand(t, "../testData/todo.yaml")
+			var got string
+			_ = command.SetHandler("todo add", func(values map[string]string) (string, error) {
+				got = values["message"]
+				return "added", nil
+			})
+
+			req, err := NewRequest(command, Interaction{Type: 2, Data: ApplicationCommandData{Name: "todo", Options: []InteractionOption{
+				{Name: "add", Type: 1, Options: []InteractionOption{{Name: "message", Type: 3, Value: test.value}}},
+			}}})
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantText, req.Text)
+
+			response, err := command.ExecuteContext(context.Background(), req)
+			assert.NoError(t, err)
+			assert.Equal(t, "added", response.Text)
+			assert.Equal(t, test.value, got)
+		})
+	}
+}
+
+func TestServeHTTPDeferred(t *testing.T) {
+	followUps := make(chan string, 1)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		followUps <- r.Method + " " + r.URL.Path + " " + string(body)
+	}))
+	defer api.Close()
+
+	release := make(chan struct{})
+	command := newTestCommand(t, "../testData/wrangler.yaml")
+	command.SetHandler("wrangler move thread", func(values map[string]string) (string, error) {
+		<-release
+		return "moved " + values["messageID"], nil
+	})
+
+	handler := newTestHandler(t, command)
+	handler.Timeout = 10 * time.Millisecond
+	handler.APIURL = api.URL
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newSignedRequest(movePayload, privateKey))
+	assert.Equal(t, `{"type":5}`+"\n", rec.Body.String())
+
+	close(release)
+	select {
+	case got := <-followUps:
+		assert.Equal(t, `PATCH /webhooks/857694315245158410/aW50ZXJhY3Rpb246ODY2/messages/@original {"content":"moved 8ehqpbrjw3f5m"}`, got)
+	case <-time.After(time.Second):
+		t.Fatal("no follow-up was sent")
+	}
+}
+
+func TestNewInteractionResponse(t *testing.T) {
+	got := NewInteractionResponse(slashparse.Response{
+		Text:        "Todo added",
+		Visibility:  slashparse.VisibilityEphemeral,
+		Attachments: []slashparse.Attachment{{Title: "buy milk", Color: "#ff0000", Fields: []slashparse.AttachmentField{{Title: "Due", Value: "today", Short: true}}}},
+		Actions: []slashparse.Action{
+			{ID: "done", Text: "Done", Value: "12", Style: "primary"},
+			{ID: "open", Text: "Open", URL: "https://example.com/todo/12"},
+		},
+	})
+
+	assert.Equal(t, InteractionResponse{
+		Type: ResponseTypeChannelMessageWithSource,
+		Data: &MessageData{
+			Content: "Todo added",
+			Flags:   64,
+			Embeds:  []Embed{{Title: "buy milk", Color: 0xff0000, Fields: []EmbedField{{Name: "Due", Value: "today", Inline: true}}}},
+			Components: []Component{{Type: 1, Components: []Component{
+				{Type: 2, Style: 1, Label: "Done", CustomID: "done:12"},
+				{Type: 2, Style: 5, Label: "Open", URL: "https://example.com/todo/12"},
+			}}},
+		},
+	}, got)
+}
+
+func TestNewHandlerInvalidKey(t *testing.T) {
+	_, err := NewHandler(&slashparse.SlashCommand{}, "not hex")
+	assert.EqualError(t, err, "the discord public key must be 64 hex characters")
+}
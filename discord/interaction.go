@@ -0,0 +1,105 @@
+package discord
+
+// Interaction types sent to the interactions endpoint
+const (
+	InteractionTypePing               = 1
+	InteractionTypeApplicationCommand = 2
+)
+
+// Interaction response types
+const (
+	ResponseTypePong                             = 1
+	ResponseTypeChannelMessageWithSource         = 4
+	ResponseTypeDeferredChannelMessageWithSource = 5
+)
+
+// flagEphemeral makes a message visible only to the user that ran the command
+const flagEphemeral = 1 << 6
+
+// Interaction is the part of a Discord interaction the adapter reads
+type Interaction struct {
+	ID            string                 `json:"id"`
+	ApplicationID string                 `json:"application_id"`
+	Type          int                    `json:"type"`
+	Data          ApplicationCommandData `json:"data"`
+	GuildID       string                 `json:"guild_id,omitempty"`
+	ChannelID     string                 `json:"channel_id,omitempty"`
+	Member        *Member                `json:"member,omitempty"`
+	User          *User                  `json:"user,omitempty"`
+	Token         string                 `json:"token"`
+}
+
+// ApplicationCommandData is the command and options a user ran
+type ApplicationCommandData struct {
+	ID      string              `json:"id"`
+	Name    string              `json:"name"`
+	Type    int                 `json:"type"`
+	Options []InteractionOption `json:"options,omitempty"`
+}
+
+// InteractionOption is a sub command, sub command group or value of an application command.
+// Value is a string, float64 or bool, depending on the option type.
+type InteractionOption struct {
+	Name    string              `json:"name"`
+	Type    int                 `json:"type"`
+	Value   interface{}         `json:"value,omitempty"`
+	Options []InteractionOption `json:"options,omitempty"`
+}
+
+// Member is the guild member that ran a command in a guild
+type Member struct {
+	User  User     `json:"user"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+// User is the Discord user that ran a command
+type User struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// InteractionResponse is the reply to an interaction
+type InteractionResponse struct {
+	Type int          `json:"type"`
+	Data *MessageData `json:"data,omitempty"`
+}
+
+// MessageData is a message sent in reply to a command
+type MessageData struct {
+	Content    string      `json:"content"`
+	Flags      int         `json:"flags,omitempty"`
+	Embeds     []Embed     `json:"embeds,omitempty"`
+	Components []Component `json:"components,omitempty"`
+}
+
+// Embed is rich content shown under a message
+type Embed struct {
+	Title       string       `json:"title,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Color       int          `json:"color,omitempty"`
+	Image       *EmbedImage  `json:"image,omitempty"`
+	Fields      []EmbedField `json:"fields,omitempty"`
+}
+
+// EmbedImage is the image of an embed
+type EmbedImage struct {
+	URL string `json:"url"`
+}
+
+// EmbedField is a titled value in an embed
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// Component is an action row or a button
+type Component struct {
+	Type       int         `json:"type"`
+	Style      int         `json:"style,omitempty"`
+	Label      string      `json:"label,omitempty"`
+	CustomID   string      `json:"custom_id,omitempty"`
+	URL        string      `json:"url,omitempty"`
+	Components []Component `json:"components,omitempty"`
+}
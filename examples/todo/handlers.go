@@ -0,0 +1,107 @@
+// Code generated by slashparse generate from todo.yaml. DO NOT EDIT.
+
+package todo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ericjaystevens/slashparse"
+)
+
+// Command paths of /todo that run a handler
+const (
+	// PathAdd is /todo add
+	PathAdd = "todo add"
+	// PathList is /todo list
+	PathList = "todo list"
+	// PathDone is /todo done
+	PathDone = "todo done"
+	// PathAssign is /todo assign
+	PathAssign = "todo assign"
+)
+
+// AddArgs are the arguments of /todo add
+type AddArgs struct {
+	// Message is the message argument: What needs to be done
+	Message string
+}
+
+// ListArgs are the arguments of /todo list
+type ListArgs struct {
+	// Status is the status argument: Which todos to list
+	Status string
+}
+
+// DoneArgs are the arguments of /todo done
+type DoneArgs struct {
+	// ID is the id argument: The ID of the todo
+	ID float64
+}
+
+// AssignArgs are the arguments of /todo assign
+type AssignArgs struct {
+	// ID is the id argument: The ID of the todo
+	ID float64
+	// User is the user argument: Who should do it
+	User string
+}
+
+// Handlers handles every command of /todo that runs a handler
+type Handlers interface {
+	// Add handles /todo add: Add a todo
+	Add(ctx context.Context, req slashparse.Request, args AddArgs) (slashparse.Response, error)
+	// List handles /todo list: List your todos
+	List(ctx context.Context, req slashparse.Request, args ListArgs) (slashparse.Response, error)
+	// Done handles /todo done: Mark a todo as done
+	Done(ctx context.Context, req slashparse.Request, args DoneArgs) (slashparse.Response, error)
+	// Assign handles /todo assign: Assign a todo to someone
+	Assign(ctx context.Context, req slashparse.Request, args AssignArgs) (slashparse.Response, error)
+}
+
+// Register sets the methods of handlers as the handlers of /todo
+func Register(command *slashparse.SlashCommand, handlers Handlers) error {
+	if err := command.SetContextHandler(PathAdd, func(ctx context.Context, req slashparse.Request, values map[string]string) (slashparse.Response, error) {
+		var args AddArgs
+		args.Message = values["message"]
+		return handlers.Add(ctx, req, args)
+	}); err != nil {
+		return err
+	}
+	if err := command.SetContextHandler(PathList, func(ctx context.Context, req slashparse.Request, values map[string]string) (slashparse.Response, error) {
+		var args ListArgs
+		args.Status = values["status"]
+		return handlers.List(ctx, req, args)
+	}); err != nil {
+		return err
+	}
+	if err := command.SetContextHandler(PathDone, func(ctx context.Context, req slashparse.Request, values map[string]string) (slashparse.Response, error) {
+		var args DoneArgs
+		if value := values["id"]; value != "" {
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return slashparse.Response{}, fmt.Errorf("--id %s is not a number", value)
+			}
+			args.ID = number
+		}
+		return handlers.Done(ctx, req, args)
+	}); err != nil {
+		return err
+	}
+	if err := command.SetContextHandler(PathAssign, func(ctx context.Context, req slashparse.Request, values map[string]string) (slashparse.Response, error) {
+		var args AssignArgs
+		if value := values["id"]; value != "" {
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return slashparse.Response{}, fmt.Errorf("--id %s is not a number", value)
+			}
+			args.ID = number
+		}
+		args.User = values["user"]
+		return handlers.Assign(ctx, req, args)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
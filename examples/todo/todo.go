@@ -0,0 +1,88 @@
+// Package todo is an example of typed handlers generated from a definition. handlers.go is generated from
+// todo.yaml, and List implements the generated Handlers interface.
+package todo
+
+//go:generate go run ../../cmd/slashparse generate todo.yaml --package todo --out handlers.go
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ericjaystevens/slashparse"
+)
+
+var _ Handlers = (*List)(nil)
+
+// List keeps todos in memory
+type List struct {
+	mu    sync.Mutex
+	todos []todo
+}
+
+type todo struct {
+	message  string
+	assignee string
+	done     bool
+}
+
+// Add adds a todo
+func (l *List) Add(ctx context.Context, req slashparse.Request, args AddArgs) (slashparse.Response, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.todos = append(l.todos, todo{message: args.Message})
+	return slashparse.TextResponse(fmt.Sprintf("Added todo %d", len(l.todos))), nil
+}
+
+// List lists todos by status
+func (l *List) List(ctx context.Context, req slashparse.Request, args ListArgs) (slashparse.Response, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var lines []string
+	for i, item := range l.todos {
+		if args.Status == "all" || item.done == (args.Status == "done") {
+			lines = append(lines, fmt.Sprintf("%d. %s", i+1, item.message))
+		}
+	}
+	if len(lines) == 0 {
+		return slashparse.TextResponse("Nothing to do"), nil
+	}
+	return slashparse.TextResponse(strings.Join(lines, "\n")), nil
+}
+
+// Done marks a todo as done
+func (l *List) Done(ctx context.Context, req slashparse.Request, args DoneArgs) (slashparse.Response, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	item, err := l.find(args.ID)
+	if err != nil {
+		return slashparse.Response{}, err
+	}
+	item.done = true
+	return slashparse.TextResponse(fmt.Sprintf("Done: %s", item.message)), nil
+}
+
+// Assign assigns a todo to a user
+func (l *List) Assign(ctx context.Context, req slashparse.Request, args AssignArgs) (slashparse.Response, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	item, err := l.find(args.ID)
+	if err != nil {
+		return slashparse.Response{}, err
+	}
+	item.assignee = args.User
+	return slashparse.TextResponse(fmt.Sprintf("Assigned %s to %s", item.message, args.User)), nil
+}
+
+func (l *List) find(id float64) (*todo, error) {
+	index := int(id) - 1
+	if index < 0 || index >= len(l.todos) || float64(index+1) != id {
+		return nil, fmt.Errorf("there is no todo %v", id)
+	}
+	return &l.todos[index], nil
+}
@@ -0,0 +1,42 @@
+package todo
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/ericjaystevens/slashparse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	def, _ := ioutil.ReadFile("todo.yaml")
+	command, _ := slashparse.NewSlashCommand(def)
+	assert.Nil(t, Register(&command, &List{}))
+	assert.Nil(t, command.Verify())
+
+	steps := []struct {
+		commandString string
+		want          string
+		wantErr       string
+	}{
+		{commandString: "/todo add water the plants", want: "Added todo 1"},
+		{commandString: "/todo add feed the cat", want: "Added todo 2"},
+		{commandString: "/todo done 1", want: "Done: water the plants"},
+		{commandString: "/todo list", want: "2. feed the cat"},
+		{commandString: "/todo list done", want: "1. water the plants"},
+		{commandString: "/todo done one", wantErr: "--id one is not a number"},
+		{commandString: "/todo done 3", wantErr: "there is no todo 3"},
+	}
+
+	for _, step := range steps {
+		t.Run(step.commandString, func(t *testing.T) {
+			got, err := command.Execute(step.commandString)
+			if step.wantErr != "" {
+				assert.EqualError(t, err, step.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, step.want, got)
+		})
+	}
+}
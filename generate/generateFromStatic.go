@@ -30,19 +30,27 @@ const jsonSchemaContent = `
 	}
 
 	outfile := "./templates.go"
-	helpTemplateFile := "templates/standardHelp.tpl"
-
-	dat, err = ioutil.ReadFile(helpTemplateFile)
-	if err != nil {
-		log.Print(err.Error())
+	helpTemplates := []struct {
+		constName string
+		file      string
+	}{
+		{"helpTemplateContent", "templates/standardHelp.tpl"},
+		{"textHelpTemplateContent", "templates/textHelp.tpl"},
+		{"htmlHelpTemplateContent", "templates/htmlHelp.tpl"},
+		{"indexHelpTemplateContent", "templates/indexHelp.tpl"},
 	}
-	helpTemplateText := strconv.Quote(string(dat))
 
-	genCode = `// THIS  FILE IS GENERATED, DO NOT EDIT, INSTEAD UPDATE templates/standardHelp.tpl and run generate/generateFromStatic.go
+	genCode = `// THIS  FILE IS GENERATED, DO NOT EDIT, INSTEAD UPDATE templates/*.tpl and run generate/generateFromStatic.go
 
 package slashparse
-
-const helpTemplateContent = ` + helpTemplateText + "\n"
+`
+	for _, helpTemplate := range helpTemplates {
+		dat, err = ioutil.ReadFile(helpTemplate.file)
+		if err != nil {
+			log.Print(err.Error())
+		}
+		genCode += "\nconst " + helpTemplate.constName + " = " + strconv.Quote(string(dat)) + "\n"
+	}
 
 	err = ioutil.WriteFile(outfile, []byte(genCode), 0644)
 	if err != nil {
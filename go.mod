@@ -1,11 +1,14 @@
 module github.com/ericjaystevens/slashparse
 
-go 1.14
+go 1.16
 
 require (
+	github.com/pelletier/go-toml v1.9.5
 	github.com/stretchr/testify v1.5.1
 	github.com/xeipuuv/gojsonschema v1.2.0
 	golang.org/x/lint v0.0.0-20200302205851-738671d3881b // indirect
+	golang.org/x/sys v0.7.0 // indirect
+	golang.org/x/term v0.0.0-20201210144234-2321bbc49cbf
 	golang.org/x/tools v0.0.0-20200730221956-1ac65761fe2c // indirect
 	gopkg.in/yaml.v2 v2.3.0
 )
@@ -0,0 +1,213 @@
+package slashparse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// HelpCommand is the model help output is rendered from, and the data passed to help templates
+type HelpCommand struct {
+	// Name is the name of the command, such as "thread"
+	Name string `json:"name"`
+	// Path is the full command path, such as "wrangler move thread"
+	Path string `json:"path"`
+	// Usage is how to call the command, such as "/wrangler move thread messageID [channelID]"
+	Usage string `json:"usage"`
+	// Description is the one line description of the command
+	Description string `json:"description"`
+	// LongDescription explains the command in more detail, empty when there is none
+	LongDescription string `json:"longDescription,omitempty"`
+	// Examples show how to run the command
+	Examples []Example `json:"examples,omitempty"`
+	// Notes are extra usage notes
+	Notes []string `json:"notes,omitempty"`
+	// Deprecated says what to use instead when the command is deprecated, empty otherwise
+	Deprecated string `json:"deprecated,omitempty"`
+	// Arguments are the arguments of the command in the order they are defined
+	Arguments []HelpArgument `json:"arguments,omitempty"`
+	// SubCommands are the commands below this one, each with their own sub commands
+	SubCommands []HelpCommand `json:"subCommands,omitempty"`
+}
+
+// HelpArgument describes an argument in help output
+type HelpArgument struct {
+	// Name is used as --name and as the key of the value passed to handlers
+	Name string `json:"name"`
+	// ShortName is used as -shortName, empty when the argument has none
+	ShortName string `json:"shortName,omitempty"`
+	// ArgType is the argument type, such as "text" or "remaining text"
+	ArgType string `json:"argType,omitempty"`
+	// Description is the one line description of the argument
+	Description string `json:"description"`
+	// Default is the static default value, empty when there is none
+	Default string `json:"default,omitempty"`
+	// Position is where the argument goes when passed without its name
+	Position int `json:"position"`
+	// Required is true when the command can't run without the argument
+	Required bool `json:"required"`
+	// Deprecated says what to use instead when the argument is deprecated, empty otherwise
+	Deprecated string `json:"deprecated,omitempty"`
+}
+
+// GetHelp returns markdown formated help for the command at commandPath, such as "wrangler move thread".
+// The help covers the command's usage, arguments and the commands below it.
+func (s *SlashCommand) GetHelp(commandPath string) (string, error) {
+	return s.GetHelpAs(HelpFormatMarkdown, commandPath)
+}
+
+// GetHelpModel returns the model help for the command at commandPath is rendered from
+func (s *SlashCommand) GetHelpModel(commandPath string) (HelpCommand, error) {
+	commandPath = strings.TrimPrefix(strings.TrimSpace(commandPath), "/")
+	if commandPath == "" || strings.EqualFold(commandPath, s.Name) {
+		return newHelpCommand(s.Name, s.asSubCommand()), nil
+	}
+
+	subCommand, err := s.getSubCommand(commandPath)
+	if err != nil {
+		return HelpCommand{}, fmt.Errorf("/%s is not a valid command. Please see /%s help", commandPath, s.Name)
+	}
+
+	return newHelpCommand(subCommand.getCommandPath(), subCommand), nil
+}
+
+func newHelpCommand(path string, subCommand SubCommand) HelpCommand {
+	helpCommand := HelpCommand{
+		Name:            subCommand.Name,
+		Path:            path,
+		Usage:           getUsage(path, visibleArguments(subCommand.Arguments)),
+		Description:     subCommand.Description,
+		LongDescription: subCommand.LongDescription,
+		Examples:        subCommand.Examples,
+		Notes:           subCommand.Notes,
+		Deprecated:      subCommand.Deprecated,
+	}
+
+	for _, arg := range subCommand.Arguments {
+		if arg.Hidden {
+			continue
+		}
+		helpCommand.Arguments = append(helpCommand.Arguments, HelpArgument{
+			Name:        arg.Name,
+			ShortName:   arg.ShortName,
+			ArgType:     arg.ArgType,
+			Description: arg.Description,
+			Default:     arg.Default,
+			Position:    arg.Position,
+			Required:    arg.Required,
+			Deprecated:  arg.Deprecated,
+		})
+	}
+
+	for _, child := range subCommand.SubCommands {
+		if child.Hidden {
+			continue
+		}
+		helpCommand.SubCommands = append(helpCommand.SubCommands, newHelpCommand(path+" "+child.Name, child))
+	}
+	return helpCommand
+}
+
+// visibleArguments returns the arguments that are not hidden
+func visibleArguments(arguments []Argument) []Argument {
+	visible := make([]Argument, 0, len(arguments))
+	for _, arg := range arguments {
+		if !arg.Hidden {
+			visible = append(visible, arg)
+		}
+	}
+	return visible
+}
+
+// getUsage builds the usage line of a command, optional arguments are in square brackets
+func getUsage(path string, arguments []Argument) string {
+	usage := "/" + strings.ToLower(path)
+	for _, arg := range arguments {
+		if arg.Required {
+			usage += " " + arg.Name
+		} else {
+			usage += " [" + arg.Name + "]"
+		}
+	}
+	return usage
+}
+
+// helpHandler is the handler of the built-in help sub command. The command value is the path below
+// the slash command to show help for. It only lists commands the caller can run.
+func (s *SlashCommand) helpHandler(ctx context.Context, req Request, values map[string]string) (Response, error) {
+	commandPath := s.Name
+	if values["command"] != "" {
+		commandPath += " " + values["command"]
+	}
+
+	allowed := s.allowedCommands(ctx, req)
+	help, err := allowed.GetHelp(commandPath)
+	if err != nil {
+		return Response{Text: err.Error(), Visibility: VisibilityEphemeral}, err
+	}
+	return Response{Text: help, Visibility: VisibilityEphemeral}, nil
+}
+
+// getHelpPath returns the command path a slash string asks help for. Help is asked for with --help
+// right after a command, with help after a command that has sub commands, as in "/wrangler move help",
+// or with the command after help, as in "/wrangler help move thread". A quoted "--help" is an argument value.
+func (s *SlashCommand) getHelpPath(slashString string) (string, bool) {
+	tokens, quoted := splitTokens(slashString)
+	for i := range tokens {
+		if quoted[i] {
+			//quoted text is never a sub command or flag, so it can't be confused with either
+			tokens[i] = "\"" + tokens[i] + "\""
+		}
+	}
+	return s.getHelpPathFromTokens(tokens)
+}
+
+// getHelpPathFromTokens is getHelpPath for a slash string that is already split into tokens.
+// Only the first token after the command path is checked for --help, the rest are arguments. Names after help
+// that aren't sub commands stay in the path, so help for it fails instead of showing help for its parent.
+func (s *SlashCommand) getHelpPathFromTokens(tokens []string) (string, bool) {
+	if len(tokens) == 0 || !strings.EqualFold(strings.TrimPrefix(tokens[0], "/"), s.Name) {
+		return "", false
+	}
+
+	path := s.Name
+	subCommands := s.SubCommands
+
+	for i, token := range tokens[1:] {
+		if token == "--help" {
+			return path, true
+		}
+
+		if strings.EqualFold(token, "help") && len(subCommands) > 0 {
+			for _, name := range tokens[i+2:] {
+				subCommand, ok := findSubCommand(subCommands, name)
+				if !ok {
+					//keep the unknown name in the path, so the help handler reports it
+					path += " " + name
+					break
+				}
+				path += " " + subCommand.Name
+				subCommands = subCommand.SubCommands
+			}
+			return path, true
+		}
+
+		subCommand, ok := findSubCommand(subCommands, token)
+		if !ok {
+			break
+		}
+		path += " " + subCommand.Name
+		subCommands = subCommand.SubCommands
+	}
+	return "", false
+}
+
+// findSubCommand finds a sub command by name, ignoring the built-in help
+func findSubCommand(subCommands []SubCommand, name string) (SubCommand, bool) {
+	for _, subCommand := range subCommands {
+		if !subCommand.builtIn && strings.EqualFold(subCommand.Name, name) {
+			return subCommand, true
+		}
+	}
+	return SubCommand{}, false
+}
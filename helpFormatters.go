@@ -0,0 +1,255 @@
+package slashparse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmlTemplate "html/template"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// HelpFormat names an output format for help
+type HelpFormat string
+
+const (
+	// HelpFormatMarkdown is the markdown help shown by the built-in help command
+	HelpFormatMarkdown HelpFormat = "md"
+	// HelpFormatText is plain text help for IRC or a terminal
+	HelpFormatText HelpFormat = "text"
+	// HelpFormatHTML is an html fragment for web documentation
+	HelpFormatHTML HelpFormat = "html"
+	// HelpFormatJSON is the help model as json, for building custom help
+	HelpFormatJSON HelpFormat = "json"
+)
+
+// HelpFormatter renders help for a command
+type HelpFormatter interface {
+	FormatHelp(command HelpCommand) (string, error)
+}
+
+// HelpFormatterFunc lets an ordinary function be used as a HelpFormatter
+type HelpFormatterFunc func(command HelpCommand) (string, error)
+
+// FormatHelp calls f
+func (f HelpFormatterFunc) FormatHelp(command HelpCommand) (string, error) {
+	return f(command)
+}
+
+// MarkdownFormatter renders help as markdown
+type MarkdownFormatter struct{}
+
+// FormatHelp renders the command with the standard help template
+func (MarkdownFormatter) FormatHelp(command HelpCommand) (string, error) {
+	return executeTextTemplate("standardHelp.tpl", helpTemplateContent, command)
+}
+
+// TextFormatter renders help as plain text
+type TextFormatter struct{}
+
+// FormatHelp renders the command with the plain text help template
+func (TextFormatter) FormatHelp(command HelpCommand) (string, error) {
+	return executeTextTemplate("textHelp.tpl", textHelpTemplateContent, command)
+}
+
+// TemplateFormatter renders help with a user supplied text/template. The template is executed
+// with a HelpCommand and can use the functions from HelpFuncMap.
+type TemplateFormatter struct {
+	helpTemplate *template.Template
+}
+
+// NewTemplateFormatter parses a help template. funcs are added to HelpFuncMap and may be nil.
+func NewTemplateFormatter(content string, funcs template.FuncMap) (*TemplateFormatter, error) {
+	helpTemplate, err := template.New("help").Funcs(HelpFuncMap()).Funcs(funcs).Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load help template. %s", err.Error())
+	}
+	return &TemplateFormatter{helpTemplate: helpTemplate}, nil
+}
+
+// FormatHelp executes the template with the command
+func (f *TemplateFormatter) FormatHelp(command HelpCommand) (string, error) {
+	var tpl bytes.Buffer
+	if err := f.helpTemplate.Execute(&tpl, command); err != nil {
+		return "", fmt.Errorf("unable to execute help template. %s", err.Error())
+	}
+	return tpl.String(), nil
+}
+
+// SetHelpTemplate replaces the markdown help of this slash command, which the built-in help command shows,
+// with a text/template
+func (s *SlashCommand) SetHelpTemplate(content string, funcs template.FuncMap) error {
+	formatter, err := NewTemplateFormatter(content, funcs)
+	if err != nil {
+		return err
+	}
+	s.SetHelpFormatter(HelpFormatMarkdown, formatter)
+	return nil
+}
+
+// SetDefaultHelpTemplate replaces the markdown help of every slash command that has no help template of its own.
+// It is meant to be called once while your application starts, ResetDefaultHelpTemplate undoes it.
+func SetDefaultHelpTemplate(content string, funcs template.FuncMap) error {
+	formatter, err := NewTemplateFormatter(content, funcs)
+	if err != nil {
+		return err
+	}
+
+	defaultHelpFormatterMu.Lock()
+	defer defaultHelpFormatterMu.Unlock()
+	defaultHelpFormatter = formatter
+	return nil
+}
+
+// ResetDefaultHelpTemplate restores the built-in markdown help for slash commands that have no help template of their own
+func ResetDefaultHelpTemplate() {
+	defaultHelpFormatterMu.Lock()
+	defer defaultHelpFormatterMu.Unlock()
+	defaultHelpFormatter = nil
+}
+
+// HelpFuncMap returns the functions available to help templates:
+//
+//	ToLower  lower cases text
+//	usage    the usage line of a HelpCommand, such as "/wrangler move thread messageID [channelID]"
+//	indent   indents every line of text by a number of spaces: {{.Description | indent 4}}
+//	wrap     wraps text at a width: {{.Description | wrap 40}}
+//	join     joins a list with a separator: {{.Notes | join ", "}}
+func HelpFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"ToLower": strings.ToLower,
+		"usage":   func(command HelpCommand) string { return command.Usage },
+		"indent":  indent,
+		"wrap":    wrap,
+		"join":    func(separator string, items []string) string { return strings.Join(items, separator) },
+	}
+}
+
+// indent adds spaces to the start of every line of text
+func indent(spaces int, text string) string {
+	padding := strings.Repeat(" ", spaces)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = padding + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrap breaks text into lines no longer than width, unless a single word is longer
+func wrap(width int, text string) string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// HTMLFormatter renders help as an html fragment, escaping descriptions
+type HTMLFormatter struct{}
+
+// FormatHelp renders the command with the html help template
+func (HTMLFormatter) FormatHelp(command HelpCommand) (string, error) {
+	helpTemplate, err := htmlTemplate.New("htmlHelp.tpl").Parse(htmlHelpTemplateContent)
+	if err != nil {
+		return "", fmt.Errorf("unable to load help template. %s", err.Error())
+	}
+
+	var tpl bytes.Buffer
+	if err := helpTemplate.Execute(&tpl, command); err != nil {
+		return "", fmt.Errorf("unable to execute help template. %s", err.Error())
+	}
+	return tpl.String(), nil
+}
+
+// JSONFormatter renders the help model as indented json
+type JSONFormatter struct{}
+
+// FormatHelp marshals the command
+func (JSONFormatter) FormatHelp(command HelpCommand) (string, error) {
+	result, err := json.MarshalIndent(command, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
+
+var builtInHelpFormatters = map[HelpFormat]HelpFormatter{
+	HelpFormatMarkdown: MarkdownFormatter{},
+	HelpFormatText:     TextFormatter{},
+	HelpFormatHTML:     HTMLFormatter{},
+	HelpFormatJSON:     JSONFormatter{},
+}
+
+// defaultHelpFormatter is the markdown formatter set with SetDefaultHelpTemplate, nil for the built-in one
+var (
+	defaultHelpFormatterMu sync.RWMutex
+	defaultHelpFormatter   HelpFormatter
+)
+
+// getDefaultHelpFormatter returns the formatter of a format for slash commands that didn't set their own
+func getDefaultHelpFormatter(format HelpFormat) (HelpFormatter, bool) {
+	format = HelpFormat(strings.ToLower(string(format)))
+	if format == HelpFormatMarkdown {
+		defaultHelpFormatterMu.RLock()
+		formatter := defaultHelpFormatter
+		defaultHelpFormatterMu.RUnlock()
+		if formatter != nil {
+			return formatter, true
+		}
+	}
+
+	formatter, ok := builtInHelpFormatters[format]
+	return formatter, ok
+}
+
+// SetHelpFormatter adds a help format, or replaces a built-in one, for this slash command
+func (s *SlashCommand) SetHelpFormatter(format HelpFormat, formatter HelpFormatter) {
+	if s.helpFormatters == nil {
+		s.helpFormatters = make(map[HelpFormat]HelpFormatter)
+	}
+	s.helpFormatters[format] = formatter
+}
+
+// GetHelpAs returns help for the command at commandPath in the given format
+func (s *SlashCommand) GetHelpAs(format HelpFormat, commandPath string) (string, error) {
+	formatter, ok := s.helpFormatters[format]
+	if !ok {
+		formatter, ok = getDefaultHelpFormatter(format)
+	}
+	if !ok {
+		return "", fmt.Errorf("unknown help format %s", format)
+	}
+
+	command, err := s.GetHelpModel(commandPath)
+	if err != nil {
+		return "", err
+	}
+	return formatter.FormatHelp(command)
+}
+
+func executeTextTemplate(name, content string, data interface{}) (string, error) {
+	helpTemplate, err := template.New(name).Funcs(HelpFuncMap()).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("unable to load help template. %s", err.Error())
+	}
+
+	var tpl bytes.Buffer
+	if err := helpTemplate.Execute(&tpl, data); err != nil {
+		return "", fmt.Errorf("unable to execute help template. %s", err.Error())
+	}
+	return tpl.String(), nil
+}
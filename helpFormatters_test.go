@@ -0,0 +1,192 @@
+package slashparse
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type getHelpAsTests struct {
+	name        string
+	format      HelpFormat
+	commandPath string
+	wantPrefix  string
+	wantContain string
+}
+
+func TestGetHelpAs(t *testing.T) {
+	tests := []getHelpAsTests{
+		{
+			name:        "markdown",
+			format:      HelpFormatMarkdown,
+			commandPath: "wrangler list",
+			wantPrefix:  "#### /wrangler list Help",
+			wantContain: "* **channels**: _List channel IDs that you have joined_",
+		},
+		{
+			name:        "plain text",
+			format:      HelpFormatText,
+			commandPath: "wrangler list",
+			wantPrefix:  "/wrangler list - Lists IDs for channels and messages",
+			wantContain: "  /wrangler list messages [count] [trim-length] [trim]\n      Shows detailed help information",
+		},
+		{
+			name:        "plain text arguments",
+			format:      HelpFormatText,
+			commandPath: "print reverse",
+			wantPrefix:  "/Print reverse - reverses back what you type.",
+			wantContain: "  text (-t): text you want to print (required)",
+		},
+		{
+			name:        "html",
+			format:      HelpFormatHTML,
+			commandPath: "wrangler list",
+			wantPrefix:  `<div class="slash-help">`,
+			wantContain: "<li><strong>channels</strong>: List channel IDs that you have joined <code>/wrangler list channels [channel-filter] [team-filter]</code></li>",
+		},
+		{
+			name:        "format is not case sensitive",
+			format:      "HTML",
+			commandPath: "wrangler list",
+			wantPrefix:  `<div class="slash-help">`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newSlash, _ := NewSlashCommand(wranglerDef)
+			if strings.HasPrefix(test.commandPath, "print") {
+				newSlash, _ = NewSlashCommand(SimpleDef)
+			}
+
+			got, err := newSlash.GetHelpAs(test.format, test.commandPath)
+
+			assert.Nil(t, err)
+			assert.True(t, strings.HasPrefix(got, test.wantPrefix), got)
+			assert.True(t, strings.Contains(got, test.wantContain), got)
+		})
+	}
+}
+
+func TestJSONFormatter(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+	got, err := newSlash.GetHelpAs(HelpFormatJSON, "wrangler move thread")
+	assert.Nil(t, err)
+
+	var model HelpCommand
+	assert.Nil(t, json.Unmarshal([]byte(got), &model))
+	assert.Equal(t, "thread", model.Name)
+	assert.Equal(t, "wrangler move thread", model.Path)
+	assert.Equal(t, "/wrangler move thread [messageID] [channelID]", model.Usage)
+	assert.Equal(t, "messageID", model.Arguments[0].Name)
+}
+
+func TestHTMLFormatterEscapes(t *testing.T) {
+	got, err := HTMLFormatter{}.FormatHelp(HelpCommand{Path: "x", Description: "<script>"})
+
+	assert.Nil(t, err)
+	assert.False(t, strings.Contains(got, "<script>"))
+}
+
+func TestSetHelpFormatter(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+	newSlash.SetHelpFormatter("usage", HelpFormatterFunc(func(command HelpCommand) (string, error) {
+		return command.Usage, nil
+	}))
+
+	got, err := newSlash.GetHelpAs("usage", "wrangler move thread")
+	assert.Nil(t, err)
+	assert.Equal(t, "/wrangler move thread [messageID] [channelID]", got)
+
+	_, err = newSlash.GetHelpAs("rtf", "wrangler")
+	assert.EqualError(t, err, "unknown help format rtf")
+}
+
+func TestSetHelpTemplate(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+	err := newSlash.SetHelpTemplate(`{{usage .}}{{range .SubCommands}}
+{{.Name | shout}}
+{{.Description | wrap 20 | indent 2}}{{end}}`, template.FuncMap{"shout": strings.ToUpper})
+	assert.Nil(t, err)
+
+	got, err := newSlash.Execute("/wrangler move help")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "/wrangler move\nTHREAD\n  Move a message and\n  the thread it\n  belongs to", got)
+}
+
+func TestHelpTemplateJoin(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+	err := newSlash.SetHelpTemplate(`{{.Notes | join ", "}}`, nil)
+	assert.Nil(t, err)
+
+	got, err := newSlash.GetHelpAs(HelpFormatMarkdown, "wrangler move thread")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "The channel defaults to the current channel., Use /wrangler list channels to find channel IDs.", got)
+}
+
+func TestHelpTemplateErrors(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+
+	err := newSlash.SetHelpTemplate("{{.Name", nil)
+	assert.NotNil(t, err)
+
+	err = newSlash.SetHelpTemplate("{{.Name.Missing}}", nil)
+	assert.Nil(t, err)
+
+	_, err = newSlash.GetSlashHelpE()
+	assert.NotNil(t, err)
+	assert.Equal(t, "", newSlash.GetSlashHelp())
+
+	_, err = newSlash.Execute("/wrangler help")
+	assert.NotNil(t, err)
+}
+
+func TestSetDefaultHelpTemplate(t *testing.T) {
+	defer ResetDefaultHelpTemplate()
+
+	err := SetDefaultHelpTemplate("{{.Path}}: {{.Description}}", nil)
+	assert.Nil(t, err)
+
+	newSlash, _ := NewSlashCommand(wranglerDef)
+	got, _ := newSlash.GetHelp("wrangler info")
+	assert.Equal(t, "wrangler info: Shows plugin information", got)
+
+	newSlash.SetHelpFormatter(HelpFormatMarkdown, MarkdownFormatter{})
+	got, _ = newSlash.GetHelp("wrangler info")
+	assert.Equal(t, "#### /wrangler info Help", strings.Split(got, "\n")[0])
+
+	ResetDefaultHelpTemplate()
+	otherSlash, _ := NewSlashCommand(wranglerDef)
+	got, _ = otherSlash.GetHelp("wrangler info")
+	assert.Equal(t, "#### /wrangler info Help", strings.Split(got, "\n")[0])
+}
+
+func TestSetDefaultHelpTemplateConcurrently(t *testing.T) {
+	defer ResetDefaultHelpTemplate()
+	newSlash, _ := NewSlashCommand(wranglerDef)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = SetDefaultHelpTemplate("{{.Path}}", nil)
+			ResetDefaultHelpTemplate()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		_, err := newSlash.GetHelp("wrangler info")
+		assert.Nil(t, err)
+	}
+	<-done
+}
+
+func TestHelpFuncs(t *testing.T) {
+	assert.Equal(t, "  a\n\n  b", indent(2, "a\n\nb"))
+	assert.Equal(t, "one two\nthree", wrap(7, "one two three"))
+	assert.Equal(t, "averyveryverylongword\nx", wrap(5, "averyveryverylongword x"))
+}
@@ -0,0 +1,204 @@
+package slashparse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type helpPathTests struct {
+	name        string
+	slashString string
+	want        string
+	wantHelp    bool
+}
+
+func TestGetHelpPath(t *testing.T) {
+	tests := []helpPathTests{
+		{
+			name:        "top level help",
+			slashString: "/wrangler help",
+			want:        "wrangler",
+			wantHelp:    true,
+		},
+		{
+			name:        "help after sub command",
+			slashString: "/wrangler move help",
+			want:        "wrangler move",
+			wantHelp:    true,
+		},
+		{
+			name:        "--help after command",
+			slashString: "/wrangler move thread --help",
+			want:        "wrangler move thread",
+			wantHelp:    true,
+		},
+		{
+			name:        "--help after arguments",
+			slashString: "/wrangler move thread abc --help",
+			wantHelp:    false,
+		},
+		{
+			name:        "quoted --help",
+			slashString: `/print quote "--help"`,
+			wantHelp:    false,
+		},
+		{
+			name:        "command after help",
+			slashString: "/wrangler help move thread",
+			want:        "wrangler move thread",
+			wantHelp:    true,
+		},
+		{
+			name:        "unknown command after help",
+			slashString: "/wrangler help nonsense",
+			want:        "wrangler nonsense",
+			wantHelp:    true,
+		},
+		{
+			name:        "unknown command after sub command help",
+			slashString: "/wrangler move help typo",
+			want:        "wrangler move typo",
+			wantHelp:    true,
+		},
+		{
+			name:        "help as an argument value",
+			slashString: "/print reverse help",
+			wantHelp:    false,
+		},
+		{
+			name:        "no help",
+			slashString: "/wrangler list channels",
+			wantHelp:    false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newSlash, _ := NewSlashCommand(wranglerDef)
+			if strings.HasPrefix(test.slashString, "/print") {
+				newSlash, _ = NewSlashCommand(SimpleDef)
+			}
+
+			got, gotHelp := newSlash.getHelpPath(test.slashString)
+
+			assert.Equal(t, test.wantHelp, gotHelp)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestQuotedHelpIsData(t *testing.T) {
+	newSlash, _ := NewSlashCommand(SimpleDef)
+	_ = newSlash.SetHandler("print reverse", func(values map[string]string) (string, error) {
+		return "reversing " + values["text"], nil
+	})
+
+	got, err := newSlash.Execute(`/print reverse "--help"`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "reversing --help", got)
+}
+
+func TestScopedHelp(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+
+	for _, slashString := range []string{"/wrangler move help", "/wrangler help move"} {
+		got, err := newSlash.Execute(slashString)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "#### /wrangler move Help", strings.Split(got, "\n")[0])
+		assert.True(t, strings.Contains(got, "`/wrangler move thread"))
+		assert.False(t, strings.Contains(got, "**copy**"))
+	}
+
+	got, err := newSlash.Execute("/wrangler list messages --help")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "#### /wrangler list messages Help", strings.Split(got, "\n")[0])
+	assert.True(t, strings.Contains(got, "(default: 20)"))
+}
+
+func TestHelpForUnknownCommand(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+
+	_, err := newSlash.Execute("/wrangler help nonsense")
+	assert.EqualError(t, err, "/wrangler nonsense is not a valid command. Please see /wrangler help")
+
+	_, err = newSlash.Execute("/wrangler move help typo")
+	assert.EqualError(t, err, "/wrangler move typo is not a valid command. Please see /wrangler help")
+}
+
+func TestGetHelp(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+
+	got, err := newSlash.GetHelp("wrangler move thread")
+	assert.Nil(t, err)
+	assert.Equal(t, "#### /wrangler move thread Help", strings.Split(got, "\n")[0])
+	assert.True(t, strings.Contains(got, "**channelID**"))
+
+	got, err = newSlash.GetHelp("wrangler")
+	assert.Nil(t, err)
+	want := newSlash.GetSlashHelp()
+	assert.Equal(t, want, got)
+
+	_, err = newSlash.GetHelp("wrangler juggle")
+	assert.EqualError(t, err, "/wrangler juggle is not a valid command. Please see /wrangler help")
+}
+
+type examplesAndNotesTests struct {
+	name         string
+	format       HelpFormat
+	wantContains []string
+}
+
+func TestHelpExamplesAndNotes(t *testing.T) {
+	tests := []examplesAndNotesTests{
+		{
+			name:   "markdown",
+			format: HelpFormatMarkdown,
+			wantContains: []string{
+				"\nMoves the root message and every reply to another channel, keeping the order of the replies.\n",
+				"#### Examples\n\n* `/wrangler move thread 8ehqpbrjw3f5m x9cakaz6fxbq8`: Move the thread of message 8ehqpbrjw3f5m to channel x9cakaz6fxbq8\n\n* `/wrangler move thread 8ehqpbrjw3f5m`\n",
+				"#### Notes\n\n* The channel defaults to the current channel.\n",
+			},
+		},
+		{
+			name:   "plain text",
+			format: HelpFormatText,
+			wantContains: []string{
+				"Examples:\n  /wrangler move thread 8ehqpbrjw3f5m x9cakaz6fxbq8\n      Move the thread",
+				"Notes:\n  - The channel defaults to the current channel.\n  - Use /wrangler list channels to find channel IDs.\n",
+			},
+		},
+		{
+			name:   "html",
+			format: HelpFormatHTML,
+			wantContains: []string{
+				"<li><code>/wrangler move thread 8ehqpbrjw3f5m</code></li>",
+				"<li>The channel defaults to the current channel.</li>",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newSlash, _ := NewSlashCommand(wranglerDef)
+			got, err := newSlash.GetHelpAs(test.format, "wrangler move thread")
+
+			assert.Nil(t, err)
+			for _, want := range test.wantContains {
+				assert.True(t, strings.Contains(got, want), got)
+			}
+		})
+	}
+
+	t.Run("help model", func(t *testing.T) {
+		newSlash, _ := NewSlashCommand(wranglerDef)
+		got, _ := newSlash.GetHelpModel("wrangler move thread")
+
+		assert.Equal(t, Example{Command: "/wrangler move thread 8ehqpbrjw3f5m"}, got.Examples[1])
+		assert.Len(t, got.Notes, 2)
+	})
+}
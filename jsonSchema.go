@@ -2,4 +2,4 @@
 
 package slashparse
 
-const jsonSchemaContent = "{\r\n  \"$id\": \"https://example.com/person.schema.json\",\r\n  \"$schema\": \"http://json-schema.org/draft-07/schema#\",\r\n  \"title\": \"SlashCommand\",\r\n  \"type\": \"object\",\r\n  \"properties\": {\r\n    \"name\": {\r\n      \"type\": \"string\",\r\n      \"description\": \"The Name of the Slash Command.\"\r\n    },\r\n    \"description\": {\r\n      \"type\": \"string\",\r\n      \"description\": \"A description of what the slash command does\"\r\n    },\r\n    \"arguments\": {\r\n      \"type\": \"array\",\r\n      \"description\": \"Pass these to your slash command\",\r\n      \"properties\": {\r\n        \"name\": {\r\n          \"type\": \"string\",\r\n          \"description\": \"Name of argument of Slash command\"\r\n        },\r\n        \"argtype\": {\r\n          \"type\": \"string\",\r\n          \"description\": \"SlashParse built-in argument types\",\r\n          \"enum\": [\"word\", \"number\", \"quoted text\", \"date\", \"time\", \"remaining text\"]\r\n        },\r\n        \"description\": {\r\n          \"type\": \"string\",\r\n          \"description\": \"Description of the argument being passed\"\r\n        },\r\n        \"errorMsg\": {\r\n          \"type\": \"string\",\r\n          \"description\": \"custom error message if argument does not meet requirements\"\r\n        },\r\n        \"position\": {\r\n          \"type\": \"number\",\r\n          \"description\": \"poition of the argument relative to the slash command\"\r\n        },\r\n        \"required\": {\r\n         \"type\": \"boolean\",\r\n         \"description\": \"If the arguemnt is required\" \r\n        }\r\n      },\r\n      \"required\": [\"name\", \"argtype\", \"description\"]\r\n    },\r\n    \"subcommands\": {\r\n      \"type\": \"array\",\r\n      \"description\": \"A Sub command of the slash command, often a noun\",\r\n      \"properties\": {\r\n        \"name\": {\r\n          \"type\": \"string\",\r\n          \"description\": \"Name of sub command\"\r\n        },\r\n        \"description\": {\r\n          \"type\": \"string\",\r\n          \"description\": \"description of sub command\"\r\n        },\r\n        \"arguments\": {\r\n          \"description\": \"Pass these to your slash sub command\",\r\n          \"properties\": {\r\n            \"name\": {\r\n              \"type\": \"string\",\r\n              \"description\": \"Name of argument of Slash sub command\"\r\n            },\r\n            \"argtype\": {\r\n              \"type\": \"string\",\r\n              \"description\": \"SlashParse built-in argument types\",\r\n              \"enum\": [\"word\", \"number\", \"quoted text\", \"date\", \"time\", \"remaining text\"]\r\n            },\r\n            \"description\": {\r\n              \"type\": \"string\",\r\n              \"description\": \"Description of the argument being passed to the sub command\"\r\n            },\r\n            \"errorMsg\": {\r\n              \"type\": \"string\",\r\n              \"description\": \"custom error message if argument does not meet requirements\"\r\n            },\r\n            \"position\": {\r\n              \"type\": \"number\",\r\n              \"description\": \"poition of the argument relative to the slash sub command\"\r\n            },\r\n            \"required\": {\r\n            \"type\": \"boolean\",\r\n            \"description\": \"Is the arguemnt required?\" \r\n            }\r\n          },\r\n          \"required\": [\"name\", \"argtype\", \"description\"]\r\n        },\r\n        \"subcommands\": {\r\n          \"type\": \"array\",\r\n          \"description\": \"a sub sub command\",\r\n          \"properties\": {\r\n            \"name\": {\r\n              \"type\": \"string\",\r\n              \"description\": \"Name of sub sub command, often an action word\"\r\n            },\r\n            \"description\": {\r\n              \"type\": \"string\",\r\n              \"description\": \"description of a sub sub command\"\r\n            },\r\n            \"arguments\": {\r\n              \"description\": \"Pass these to your slash sub-sub command\",\r\n              \"properties\": {\r\n                \"name\": {\r\n                  \"type\": \"string\",\r\n                  \"description\": \"Name of argument of Slash sub-sub command\"\r\n                },\r\n                \"argtype\": {\r\n                  \"type\": \"string\",\r\n                  \"description\": \"SlashParse built-in argument types\",\r\n                  \"enum\": [\"word\", \"number\", \"quoted text\", \"date\", \"time\", \"remaining text\"]\r\n                },\r\n                \"description\": {\r\n                  \"type\": \"string\",\r\n                  \"description\": \"Description of the argument being passed to the sub-sub command\"\r\n                },\r\n                \"errorMsg\": {\r\n                  \"type\": \"string\",\r\n                  \"description\": \"custom error message if argument does not meet requirements\"\r\n                },\r\n                \"position\": {\r\n                  \"type\": \"number\",\r\n                  \"description\": \"poition of the argument relative to the slash sub-sub command\"\r\n                },\r\n                \"required\": {\r\n                \"type\": \"boolean\",\r\n                \"description\": \"If the arguemnt is required\" \r\n                }\r\n              },\r\n              \"required\": [\"name\", \"argtype\", \"description\"]\r\n            }\r\n          },\r\n          \"required\": [\"name\", \"description\"]\r\n        }\r\n      },\r\n      \"required\": [\"name\", \"description\"]\r\n    }\r\n  },\r\n  \"required\": [\"name\", \"description\"]\r\n}"
+const jsonSchemaContent = "{\n  \"$id\": \"https://example.com/person.schema.json\",\n  \"$schema\": \"http://json-schema.org/draft-07/schema#\",\n  \"title\": \"SlashCommand\",\n  \"type\": \"object\",\n  \"properties\": {\n    \"name\": {\n      \"type\": \"string\",\n      \"description\": \"The Name of the Slash Command.\"\n    },\n    \"description\": {\n      \"type\": \"string\",\n      \"description\": \"A description of what the slash command does\"\n    },\n    \"longDescription\": {\n      \"type\": \"string\",\n      \"description\": \"a longer explanation of the slash command, shown in its help\"\n    },\n    \"examples\": {\n      \"type\": \"array\",\n      \"description\": \"examples of running the slash command\",\n      \"items\": {\n        \"type\": \"object\",\n        \"properties\": {\n          \"command\": {\n            \"type\": \"string\",\n            \"minLength\": 1,\n            \"description\": \"the example command, such as /wrangler move thread 1234\"\n          },\n          \"description\": {\n            \"type\": \"string\",\n            \"description\": \"what the example does\"\n          }\n        },\n        \"required\": [\"command\"]\n      }\n    },\n    \"notes\": {\n      \"type\": \"array\",\n      \"description\": \"usage notes for the slash command\",\n      \"items\": { \"type\": \"string\" }\n    },\n    \"arguments\": {\n      \"type\": \"array\",\n      \"description\": \"Pass these to your slash command\",\n      \"properties\": {\n        \"name\": {\n          \"type\": \"string\",\n          \"description\": \"Name of argument of Slash command\"\n        },\n        \"argtype\": {\n          \"type\": \"string\",\n          \"description\": \"SlashParse built-in argument types\",\n          \"enum\": [\"word\", \"number\", \"quoted text\", \"date\", \"time\", \"remaining text\"]\n        },\n        \"description\": {\n          \"type\": \"string\",\n          \"description\": \"Description of the argument being passed\"\n        },\n        \"errorMsg\": {\n          \"type\": \"string\",\n          \"description\": \"custom error message if argument does not meet requirements\"\n        },\n        \"defaultEnv\": {\n          \"type\": \"string\",\n          \"description\": \"environment variable to read the default value from\"\n        },\n        \"defaultContext\": {\n          \"type\": \"string\",\n          \"description\": \"request context value, such as channelID, to use as the default value\"\n        },\n        \"defaultFunc\": {\n          \"type\": \"string\",\n          \"description\": \"name of a registered function that returns the default value\"\n        },\n        \"hidden\": {\n          \"type\": \"boolean\",\n          \"description\": \"hide the argument from help and autocomplete, it still runs\"\n        },\n        \"deprecated\": {\n          \"type\": \"string\",\n          \"description\": \"marks the argument deprecated, the message should say what to use instead\"\n        },\n        \"hint\": {\n          \"type\": \"string\",\n          \"description\": \"placeholder shown while autocompleting the argument\"\n        },\n        \"choices\": {\n          \"type\": \"array\",\n          \"description\": \"the values the argument accepts\",\n          \"items\": {\n            \"type\": \"object\",\n            \"properties\": {\n              \"value\": {\n                \"type\": \"string\",\n                \"description\": \"an accepted value\"\n              },\n              \"description\": {\n                \"type\": \"string\",\n                \"description\": \"what the value means\"\n              }\n            },\n            \"required\": [\"value\"]\n          }\n        },\n        \"choicesURL\": {\n          \"type\": \"string\",\n          \"description\": \"url autocomplete fetches the choices of the argument from\"\n        },\n        \"position\": {\n          \"type\": \"number\",\n          \"description\": \"poition of the argument relative to the slash command\"\n        },\n        \"required\": {\n         \"type\": \"boolean\",\n         \"description\": \"If the arguemnt is required\" \n        }\n      },\n      \"required\": [\"name\", \"argtype\", \"description\"]\n    },\n    \"roles\": {\n      \"type\": \"array\",\n      \"description\": \"roles allowed to run the slash command, any one is enough\",\n      \"items\": { \"type\": \"string\" }\n    },\n    \"permissions\": {\n      \"type\": \"array\",\n      \"description\": \"permissions allowed to run the slash command, any one is enough\",\n      \"items\": { \"type\": \"string\" }\n    },\n    \"hidden\": {\n      \"type\": \"boolean\",\n      \"description\": \"hide the slash command from help and autocomplete, it still runs\"\n    },\n    \"deprecated\": {\n      \"type\": \"string\",\n      \"description\": \"marks the slash command deprecated, the message should say what to use instead\"\n    },\n    \"subcommands\": {\n      \"type\": \"array\",\n      \"description\": \"A Sub command of the slash command, often a noun\",\n      \"items\": {\n        \"type\": \"object\",\n        \"properties\": {\n          \"name\": {\n            \"type\": \"string\",\n            \"description\": \"Name of sub command\"\n          },\n          \"description\": {\n            \"type\": \"string\",\n            \"description\": \"description of sub command\"\n          },\n          \"longDescription\": {\n            \"type\": \"string\",\n            \"description\": \"a longer explanation of the sub command, shown in its help\"\n          },\n          \"examples\": {\n            \"type\": \"array\",\n            \"description\": \"examples of running the sub command\",\n            \"items\": {\n              \"type\": \"object\",\n              \"properties\": {\n                \"command\": {\n                  \"type\": \"string\",\n                  \"minLength\": 1,\n                  \"description\": \"the example command, such as /wrangler move thread 1234\"\n                },\n                \"description\": {\n                  \"type\": \"string\",\n                  \"description\": \"what the example does\"\n                }\n              },\n              \"required\": [\"command\"]\n            }\n          },\n          \"notes\": {\n            \"type\": \"array\",\n            \"description\": \"usage notes for the sub command\",\n            \"items\": { \"type\": \"string\" }\n          },\n          \"arguments\": {\n            \"description\": \"Pass these to your slash sub command\",\n            \"properties\": {\n              \"name\": {\n                \"type\": \"string\",\n                \"description\": \"Name of argument of Slash sub command\"\n              },\n              \"argtype\": {\n                \"type\": \"string\",\n                \"description\": \"SlashParse built-in argument types\",\n                \"enum\": [\"word\", \"number\", \"quoted text\", \"date\", \"time\", \"remaining text\"]\n              },\n              \"description\": {\n                \"type\": \"string\",\n                \"description\": \"Description of the argument being passed to the sub command\"\n              },\n              \"errorMsg\": {\n                \"type\": \"string\",\n                \"description\": \"custom error message if argument does not meet requirements\"\n              },\n              \"defaultEnv\": {\n                \"type\": \"string\",\n                \"description\": \"environment variable to read the default value from\"\n              },\n              \"defaultContext\": {\n                \"type\": \"string\",\n                \"description\": \"request context value, such as channelID, to use as the default value\"\n              },\n              \"defaultFunc\": {\n                \"type\": \"string\",\n                \"description\": \"name of a registered function that returns the default value\"\n              },\n              \"hidden\": {\n                \"type\": \"boolean\",\n                \"description\": \"hide the argument from help and autocomplete, it still runs\"\n              },\n              \"deprecated\": {\n                \"type\": \"string\",\n                \"description\": \"marks the argument deprecated, the message should say what to use instead\"\n              },\n              \"hint\": {\n                \"type\": \"string\",\n                \"description\": \"placeholder shown while autocompleting the argument\"\n              },\n              \"choices\": {\n                \"type\": \"array\",\n                \"description\": \"the values the argument accepts\",\n                \"items\": {\n                  \"type\": \"object\",\n                  \"properties\": {\n                    \"value\": {\n                      \"type\": \"string\",\n                      \"description\": \"an accepted value\"\n                    },\n                    \"description\": {\n                      \"type\": \"string\",\n                      \"description\": \"what the value means\"\n                    }\n                  },\n                  \"required\": [\"value\"]\n                }\n              },\n              \"choicesURL\": {\n                \"type\": \"string\",\n                \"description\": \"url autocomplete fetches the choices of the argument from\"\n              },\n              \"position\": {\n                \"type\": \"number\",\n                \"description\": \"poition of the argument relative to the slash sub command\"\n              },\n              \"required\": {\n              \"type\": \"boolean\",\n              \"description\": \"Is the arguemnt required?\" \n              }\n            },\n            \"required\": [\"name\", \"argtype\", \"description\"]\n          },\n          \"roles\": {\n            \"type\": \"array\",\n            \"description\": \"roles allowed to run the sub command, any one is enough\",\n            \"items\": { \"type\": \"string\" }\n          },\n          \"permissions\": {\n            \"type\": \"array\",\n            \"description\": \"permissions allowed to run the sub command, any one is enough\",\n            \"items\": { \"type\": \"string\" }\n          },\n          \"hidden\": {\n            \"type\": \"boolean\",\n            \"description\": \"hide the sub command from help and autocomplete, it still runs\"\n          },\n          \"deprecated\": {\n            \"type\": \"string\",\n            \"description\": \"marks the sub command deprecated, the message should say what to use instead\"\n          },\n          \"subcommands\": {\n            \"type\": \"array\",\n            \"description\": \"a sub sub command\",\n            \"items\": {\n              \"type\": \"object\",\n              \"properties\": {\n                \"name\": {\n                  \"type\": \"string\",\n                  \"description\": \"Name of sub sub command, often an action word\"\n                },\n                \"description\": {\n                  \"type\": \"string\",\n                  \"description\": \"description of a sub sub command\"\n                },\n                \"hidden\": {\n                  \"type\": \"boolean\",\n                  \"description\": \"hide the sub sub command from help and autocomplete, it still runs\"\n                },\n                \"deprecated\": {\n                  \"type\": \"string\",\n                  \"description\": \"marks the sub sub command deprecated, the message should say what to use instead\"\n                },\n                \"longDescription\": {\n                  \"type\": \"string\",\n                  \"description\": \"a longer explanation of the sub sub command, shown in its help\"\n                },\n                \"examples\": {\n                  \"type\": \"array\",\n                  \"description\": \"examples of running the sub sub command\",\n                  \"items\": {\n                    \"type\": \"object\",\n                    \"properties\": {\n                      \"command\": {\n                        \"type\": \"string\",\n                        \"minLength\": 1,\n                        \"description\": \"the example command, such as /wrangler move thread 1234\"\n                      },\n                      \"description\": {\n                        \"type\": \"string\",\n                        \"description\": \"what the example does\"\n                      }\n                    },\n                    \"required\": [\"command\"]\n                  }\n                },\n                \"notes\": {\n                  \"type\": \"array\",\n                  \"description\": \"usage notes for the sub sub command\",\n                  \"items\": { \"type\": \"string\" }\n                },\n                \"roles\": {\n                  \"type\": \"array\",\n                  \"description\": \"roles allowed to run the sub sub command, any one is enough\",\n                  \"items\": { \"type\": \"string\" }\n                },\n                \"permissions\": {\n                  \"type\": \"array\",\n                  \"description\": \"permissions allowed to run the sub sub command, any one is enough\",\n                  \"items\": { \"type\": \"string\" }\n                },\n                \"arguments\": {\n                  \"description\": \"Pass these to your slash sub-sub command\",\n                  \"properties\": {\n                    \"name\": {\n                      \"type\": \"string\",\n                      \"description\": \"Name of argument of Slash sub-sub command\"\n                    },\n                    \"argtype\": {\n                      \"type\": \"string\",\n                      \"description\": \"SlashParse built-in argument types\",\n                      \"enum\": [\"word\", \"number\", \"quoted text\", \"date\", \"time\", \"remaining text\"]\n                    },\n                    \"description\": {\n                      \"type\": \"string\",\n                      \"description\": \"Description of the argument being passed to the sub-sub command\"\n                    },\n                    \"errorMsg\": {\n                      \"type\": \"string\",\n                      \"description\": \"custom error message if argument does not meet requirements\"\n                    },\n                    \"defaultEnv\": {\n                      \"type\": \"string\",\n                      \"description\": \"environment variable to read the default value from\"\n                    },\n                    \"defaultContext\": {\n                      \"type\": \"string\",\n                      \"description\": \"request context value, such as channelID, to use as the default value\"\n                    },\n                    \"defaultFunc\": {\n                      \"type\": \"string\",\n                      \"description\": \"name of a registered function that returns the default value\"\n                    },\n                    \"hidden\": {\n                      \"type\": \"boolean\",\n                      \"description\": \"hide the argument from help and autocomplete, it still runs\"\n                    },\n                    \"deprecated\": {\n                      \"type\": \"string\",\n                      \"description\": \"marks the argument deprecated, the message should say what to use instead\"\n                    },\n                    \"hint\": {\n                      \"type\": \"string\",\n                      \"description\": \"placeholder shown while autocompleting the argument\"\n                    },\n                    \"choices\": {\n                      \"type\": \"array\",\n                      \"description\": \"the values the argument accepts\",\n                      \"items\": {\n                        \"type\": \"object\",\n                        \"properties\": {\n                          \"value\": {\n                            \"type\": \"string\",\n                            \"description\": \"an accepted value\"\n                          },\n                          \"description\": {\n                            \"type\": \"string\",\n                            \"description\": \"what the value means\"\n                          }\n                        },\n                        \"required\": [\"value\"]\n                      }\n                    },\n                    \"choicesURL\": {\n                      \"type\": \"string\",\n                      \"description\": \"url autocomplete fetches the choices of the argument from\"\n                    },\n                    \"position\": {\n                      \"type\": \"number\",\n                      \"description\": \"poition of the argument relative to the slash sub-sub command\"\n                    },\n                    \"required\": {\n                    \"type\": \"boolean\",\n                    \"description\": \"If the arguemnt is required\" \n                    }\n                  },\n                  \"required\": [\"name\", \"argtype\", \"description\"]\n                }\n              },\n              \"required\": [\"name\", \"description\"]\n            }\n          }\n        },\n        \"required\": [\"name\", \"description\"]\n      }\n    }\n  },\n  \"required\": [\"name\", \"description\"]\n}"
@@ -0,0 +1,130 @@
+package slashparse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LintIssue is a problem in a definition that the schema doesn't catch, such as an argument without a type
+type LintIssue struct {
+	Path    string
+	Message string
+}
+
+func (i LintIssue) String() string {
+	return "/" + i.Path + ": " + i.Message
+}
+
+// argTypes are the argument types parsing supports
+var argTypes = map[string]bool{
+	"text":           true,
+	"quoted text":    true,
+	"number":         true,
+	"remaining text": true,
+}
+
+// Lint checks the definition for mistakes that still load, such as an argument without a type, arguments sharing
+// a position or short name, and defaults that are not one of the choices. Unknown keys are ignored when loading,
+// so they aren't reported, a misspelled argtype only shows up as an argument without a type.
+func (s *SlashCommand) Lint() []LintIssue {
+	var issues []LintIssue
+	lintCommand(s.Name, s.asSubCommand(), &issues)
+	return issues
+}
+
+func lintCommand(path string, command SubCommand, issues *[]LintIssue) {
+	report := func(format string, args ...interface{}) {
+		*issues = append(*issues, LintIssue{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if strings.TrimSpace(command.Description) == "" {
+		report("has no description")
+	}
+	if strings.ContainsAny(command.Name, " \t") {
+		report("name %q has spaces", command.Name)
+	}
+	for _, example := range command.Examples {
+		if !strings.HasPrefix(strings.ToLower(example.Command), "/"+strings.ToLower(path)) {
+			report("example %q doesn't run /%s", example.Command, path)
+		}
+	}
+
+	names := make(map[string]bool)
+	shortNames := make(map[string]string)
+	positions := make(map[int]string)
+	for _, arg := range command.Arguments {
+		argPath := "--" + arg.Name
+		switch {
+		case arg.Name == "":
+			report("has an argument without a name")
+		case names[arg.Name]:
+			report("has more than one argument named %s", arg.Name)
+		}
+		names[arg.Name] = true
+
+		if arg.ArgType == "" {
+			report("%s has no argtype", argPath)
+		} else if !argTypes[arg.ArgType] {
+			report("%s has unknown argtype %q", argPath, arg.ArgType)
+		}
+		if strings.TrimSpace(arg.Description) == "" {
+			report("%s has no description", argPath)
+		}
+
+		if arg.ShortName != "" {
+			if other, ok := shortNames[arg.ShortName]; ok {
+				report("%s and --%s share the short name -%s", argPath, other, arg.ShortName)
+			}
+			shortNames[arg.ShortName] = arg.Name
+		}
+		//arguments without a position are only passed by name
+		if arg.Position > 0 {
+			if other, ok := positions[arg.Position]; ok {
+				report("%s and --%s share position %d", argPath, other, arg.Position)
+			}
+			positions[arg.Position] = arg.Name
+		}
+
+		if arg.Default != "" && len(arg.Choices) > 0 && !isChoice(arg, arg.Default) {
+			report("%s default %s is not one of its choices", argPath, arg.Default)
+		}
+		if arg.Required && arg.Hidden {
+			report("%s is required but hidden", argPath)
+		}
+	}
+
+	for _, arg := range command.Arguments {
+		if arg.ArgType == "remaining text" {
+			for _, other := range command.Arguments {
+				if other.Position > arg.Position {
+					report("--%s takes the remaining text, so --%s can't be passed by position", arg.Name, other.Name)
+				}
+			}
+		}
+	}
+
+	subCommandNames := make(map[string]bool)
+	for _, subCommand := range command.SubCommands {
+		if subCommand.builtIn {
+			continue
+		}
+		if subCommandNames[strings.ToLower(subCommand.Name)] {
+			report("has more than one sub command named %s", subCommand.Name)
+		}
+		subCommandNames[strings.ToLower(subCommand.Name)] = true
+		if strings.EqualFold(subCommand.Name, "help") && !strings.Contains(path, " ") {
+			report("sub command help hides the built-in help")
+		}
+		lintCommand(path+" "+subCommand.Name, subCommand, issues)
+	}
+}
+
+//isChoice checks value is one of the choices of arg
+func isChoice(arg Argument, value string) bool {
+	for _, choice := range arg.Choices {
+		if strings.EqualFold(choice.Value, value) {
+			return true
+		}
+	}
+	return false
+}
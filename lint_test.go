@@ -0,0 +1,116 @@
+package slashparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name string
+		def  string
+		want []LintIssue
+	}{
+		{
+			name: "clean",
+			def:  string(todoDef),
+		},
+		{
+			name: "argument types",
+			def: `
+name: print
+description: Print text
+arguments:
+  - name: text
+    argType: text
+    description: the text
+  - name: upper
+    argtype: switch
+    description: print in upper case
+    position: 1`,
+			want: []LintIssue{
+				{"print", "--text has no argtype"},
+				{"print", `--upper has unknown argtype "switch"`},
+			},
+		},
+		{
+			name: "clashing arguments",
+			def: `
+name: print
+description: Print text
+subcommands:
+  - name: quote
+    description: print a quote
+    arguments:
+      - name: text
+        argtype: remaining text
+        description: the quote
+        shortName: t
+      - name: author
+        argtype: text
+        description: who said it
+        shortName: t
+        position: 1
+      - name: style
+        argtype: text
+        description: how to print it
+        default: bold
+        position: 1
+        choices:
+          - value: plain
+          - value: italic`,
+			want: []LintIssue{
+				{"print quote", "--author and --text share the short name -t"},
+				{"print quote", "--style and --author share position 1"},
+				{"print quote", "--style default bold is not one of its choices"},
+				{"print quote", "--text takes the remaining text, so --author can't be passed by position"},
+				{"print quote", "--text takes the remaining text, so --style can't be passed by position"},
+			},
+		},
+		{
+			name: "named only arguments",
+			def: `
+name: print
+description: Print text
+arguments:
+  - name: upper
+    argtype: text
+    description: print in upper case
+  - name: color
+    argtype: text
+    description: the color to print in`,
+		},
+		{
+			name: "commands",
+			def: `
+name: print
+description: Print text
+examples:
+  - command: /echo hello
+subcommands:
+  - name: help
+    description: my own help
+  - name: reverse
+    description: " "`,
+			want: []LintIssue{
+				{"print", `example "/echo hello" doesn't run /print`},
+				{"print", "sub command help hides the built-in help"},
+				{"print reverse", "has no description"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newSlash, err := NewSlashCommand([]byte(test.def))
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, newSlash.Lint())
+		})
+	}
+}
+
+func TestLintIssueString(t *testing.T) {
+	issue := LintIssue{"print quote", "has no description"}
+	assert.Equal(t, "/print quote: has no description", issue.String())
+}
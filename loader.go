@@ -0,0 +1,153 @@
+package slashparse
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"gopkg.in/yaml.v2"
+)
+
+// DefinitionFormat names a file format slash command definitions can be written in
+type DefinitionFormat string
+
+const (
+	// FormatYAML is the format read by NewSlashCommand
+	FormatYAML DefinitionFormat = "yaml"
+	// FormatJSON uses the json tags of SlashCommand
+	FormatJSON DefinitionFormat = "json"
+	// FormatTOML uses the same keys as json
+	FormatTOML DefinitionFormat = "toml"
+)
+
+// FormatFromExtension returns the format of a definition file by its extension
+func FormatFromExtension(filename string) (DefinitionFormat, error) {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".json":
+		return FormatJSON, nil
+	case ".toml":
+		return FormatTOML, nil
+	}
+	return "", fmt.Errorf("unable to tell the format of %s, use .yaml, .yml, .json or .toml", filename)
+}
+
+// NewSlashCommandFromFormat creates a slash command from a definition in the given format
+func NewSlashCommandFromFormat(slashDef []byte, format DefinitionFormat) (SlashCommand, error) {
+	var s SlashCommand
+	if err := unmarshalDefinition(slashDef, format, &s); err != nil {
+		return s, err
+	}
+	return InitSlashCommand(s)
+}
+
+// LoadSlashCommand reads a definition file, telling its format by the extension.
+// Sub commands can be split into other files in the directory of the definition or below it, see LoadSlashCommandFS.
+func LoadSlashCommand(filename string) (SlashCommand, error) {
+	return LoadSlashCommandFS(os.DirFS(filepath.Dir(filename)), filepath.Base(filename))
+}
+
+// LoadSlashCommandFS reads the definition named name from fsys, such as an embed.FS or os.DirFS, telling
+// its format by the extension. A sub command can be kept in a file of its own and included by reference:
+//
+//	subcommands:
+//	  - include: commands/move.yaml
+//
+// The path is relative to the file that includes it, and the included file may be in any format.
+// Keys in the included file replace the ones next to include. Included files must be in fsys, so an include
+// can't point above its root, such as ../shared/move.yaml next to the definition LoadSlashCommand reads.
+func LoadSlashCommandFS(fsys fs.FS, name string) (SlashCommand, error) {
+	var s SlashCommand
+	if err := readDefinition(fsys, name, &s); err != nil {
+		return s, err
+	}
+
+	for i := range s.SubCommands {
+		if err := resolveInclude(fsys, path.Dir(name), &s.SubCommands[i], 1); err != nil {
+			return s, err
+		}
+	}
+	return InitSlashCommand(s)
+}
+
+// resolveInclude replaces a sub command that includes a file with the content of that file. Sub commands
+// nest two deep, so includes in deeper files are left for InitSlashCommand to report.
+func resolveInclude(fsys fs.FS, dir string, subCommand *SubCommand, depth int) error {
+	if subCommand.Include != "" {
+		name := path.Join(dir, subCommand.Include)
+		if path.IsAbs(subCommand.Include) || !fs.ValidPath(name) {
+			return fmt.Errorf("unable to include %s, included files must be in the directory of the definition or below it", subCommand.Include)
+		}
+		subCommand.Include = ""
+		if err := readDefinition(fsys, name, subCommand); err != nil {
+			return err
+		}
+		dir = path.Dir(name)
+	}
+
+	if depth == 2 {
+		return nil
+	}
+	for i := range subCommand.SubCommands {
+		if err := resolveInclude(fsys, dir, &subCommand.SubCommands[i], depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readDefinition unmarshals the file name from fsys into definition
+func readDefinition(fsys fs.FS, name string, definition interface{}) error {
+	format, err := FormatFromExtension(name)
+	if err != nil {
+		return err
+	}
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return err
+	}
+	if err := unmarshalDefinition(content, format, definition); err != nil {
+		return fmt.Errorf("unable to read %s. %s", name, err.Error())
+	}
+	return nil
+}
+
+func unmarshalDefinition(content []byte, format DefinitionFormat, definition interface{}) error {
+	switch format {
+	case FormatYAML:
+		return yaml.Unmarshal(content, definition)
+	case FormatJSON:
+		return json.Unmarshal(content, definition)
+	case FormatTOML:
+		// go through json so toml keys are the json keys
+		tree, err := toml.LoadBytes(content)
+		if err != nil {
+			return err
+		}
+		asJSON, err := json.Marshal(tree.ToMap())
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(asJSON, definition)
+	}
+	return fmt.Errorf("unknown definition format %s", format)
+}
+
+// findInclude returns the first include that wasn't resolved
+func findInclude(subCommands []SubCommand) (string, bool) {
+	for _, subCommand := range subCommands {
+		if subCommand.Include != "" {
+			return subCommand.Include, true
+		}
+		if include, ok := findInclude(subCommand.SubCommands); ok {
+			return include, true
+		}
+	}
+	return "", false
+}
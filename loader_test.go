@@ -0,0 +1,147 @@
+package slashparse
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatFromExtension(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     DefinitionFormat
+		wantErr  string
+	}{
+		{filename: "todo.yaml", want: FormatYAML},
+		{filename: "todo.YML", want: FormatYAML},
+		{filename: "commands/todo.json", want: FormatJSON},
+		{filename: "todo.toml", want: FormatTOML},
+		{filename: "todo.txt", wantErr: "unable to tell the format of todo.txt, use .yaml, .yml, .json or .toml"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filename, func(t *testing.T) {
+			got, err := FormatFromExtension(test.filename)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestNewSlashCommandFromFormat(t *testing.T) {
+	want, _ := NewSlashCommand(todoDef)
+	jsonDef, _ := ioutil.ReadFile("./testData/todo.json")
+	tomlDef, _ := ioutil.ReadFile("./testData/todo.toml")
+
+	tests := []struct {
+		name   string
+		def    []byte
+		format DefinitionFormat
+	}{
+		{"yaml", todoDef, FormatYAML},
+		{"json", jsonDef, FormatJSON},
+		{"toml", tomlDef, FormatTOML},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NewSlashCommandFromFormat(test.def, test.format)
+			assert.Nil(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := NewSlashCommandFromFormat(todoDef, "xml")
+		assert.EqualError(t, err, "unknown definition format xml")
+	})
+}
+
+func TestLoadSlashCommand(t *testing.T) {
+	todo, _ := NewSlashCommand(todoDef)
+	wrangler, _ := NewSlashCommand(wranglerDef)
+
+	tests := []struct {
+		name     string
+		filename string
+		want     SlashCommand
+		wantErr  string
+	}{
+		{name: "json", filename: "testData/todo.json", want: todo},
+		{name: "toml", filename: "testData/todo.toml", want: todo},
+		{name: "sub commands in other files", filename: "testData/split/wrangler.yaml", want: wrangler},
+		{name: "missing file", filename: "testData/missing.yaml", wantErr: "open missing.yaml: no such file or directory"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := LoadSlashCommand(test.filename)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestLoadSlashCommandIncludeAbove(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, "defs"), 0755))
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, "shared"), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "defs", "print.yaml"), []byte("name: print\ndescription: Prints things\nsubcommands:\n  - include: ../shared/reverse.json\n"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "shared", "reverse.json"), []byte(`{"name": "reverse", "description": "Prints things backwards"}`), 0644))
+
+	_, err := LoadSlashCommand(filepath.Join(dir, "defs", "print.yaml"))
+
+	assert.EqualError(t, err, "unable to include ../shared/reverse.json, included files must be in the directory of the definition or below it")
+}
+
+func TestLoadSlashCommandFS(t *testing.T) {
+	printFile := &fstest.MapFile{Data: []byte("name: print\ndescription: Prints things\nsubcommands:\n  - include: reverse.json\n")}
+	reverseFile := &fstest.MapFile{Data: []byte(`{"name": "reverse", "description": "Prints things backwards"}`)}
+
+	t.Run("includes are relative to the including file", func(t *testing.T) {
+		got, err := LoadSlashCommandFS(fstest.MapFS{"defs/print.yaml": printFile, "defs/reverse.json": reverseFile}, "defs/print.yaml")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"print", "print reverse"}, got.CommandPaths())
+	})
+
+	t.Run("missing include", func(t *testing.T) {
+		_, err := LoadSlashCommandFS(fstest.MapFS{"print.yaml": printFile}, "print.yaml")
+
+		assert.EqualError(t, err, "open reverse.json: file does not exist")
+	})
+
+	t.Run("includes outside the definition directory", func(t *testing.T) {
+		for _, include := range []string{"../../shared/reverse.json", "/defs/reverse.json"} {
+			printFile := &fstest.MapFile{Data: []byte("name: print\ndescription: Prints things\nsubcommands:\n  - include: " + include + "\n")}
+			_, err := LoadSlashCommandFS(fstest.MapFS{"defs/print.yaml": printFile, "defs/reverse.json": reverseFile}, "defs/print.yaml")
+
+			assert.EqualError(t, err, "unable to include "+include+", included files must be in the directory of the definition or below it")
+		}
+	})
+
+	t.Run("includes need files", func(t *testing.T) {
+		_, err := NewSlashCommand(printFile.Data)
+
+		assert.EqualError(t, err, "sub command include reverse.json can only be used when loading from files")
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		got, err := LoadSlashCommandFS(os.DirFS("testData"), "todo.toml")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "todo", got.Name)
+	})
+}
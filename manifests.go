@@ -0,0 +1,250 @@
+package slashparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ExportIssue describes a part of a definition that a platform can't represent as is
+type ExportIssue struct {
+	Path    string
+	Message string
+}
+
+func (i ExportIssue) String() string {
+	return "/" + i.Path + ": " + i.Message
+}
+
+// SlackCommand is a slash_commands entry of a Slack app manifest
+type SlackCommand struct {
+	Command      string `json:"command" yaml:"command"`
+	URL          string `json:"url" yaml:"url"`
+	Description  string `json:"description" yaml:"description"`
+	UsageHint    string `json:"usage_hint,omitempty" yaml:"usage_hint,omitempty"`
+	ShouldEscape bool   `json:"should_escape" yaml:"should_escape"`
+}
+
+const (
+	slackCommandMaxLength     = 32
+	slackDescriptionMaxLength = 2000
+	slackUsageHintMaxLength   = 1000
+)
+
+// GetSlackCommand converts the slash command into a Slack app manifest slash_commands entry that
+// posts to url. Slack has no sub commands, so they are listed in the usage hint.
+func (s *SlashCommand) GetSlackCommand(url string) (SlackCommand, []ExportIssue) {
+	var issues []ExportIssue
+
+	command := SlackCommand{
+		Command:     "/" + strings.ToLower(s.Name),
+		URL:         url,
+		Description: s.Description,
+		UsageHint:   getCommandHint(s.asSubCommand()),
+	}
+
+	var subCommandNames []string
+	for _, subCommand := range s.SubCommands {
+		if !subCommand.Hidden {
+			subCommandNames = append(subCommandNames, subCommand.Name)
+		}
+	}
+	if len(subCommandNames) > 0 {
+		command.UsageHint = "[" + strings.Join(subCommandNames, "|") + "]"
+	}
+
+	if utf8.RuneCountInString(command.Command) > slackCommandMaxLength {
+		issues = append(issues, ExportIssue{s.Name, fmt.Sprintf("Slack commands can be at most %d characters", slackCommandMaxLength)})
+	}
+	if utf8.RuneCountInString(command.Description) > slackDescriptionMaxLength {
+		command.Description = truncate(command.Description, slackDescriptionMaxLength)
+		issues = append(issues, ExportIssue{s.Name, fmt.Sprintf("description was cut to %d characters", slackDescriptionMaxLength)})
+	}
+	if utf8.RuneCountInString(command.UsageHint) > slackUsageHintMaxLength {
+		command.UsageHint = truncate(command.UsageHint, slackUsageHintMaxLength)
+		issues = append(issues, ExportIssue{s.Name, fmt.Sprintf("usage hint was cut to %d characters", slackUsageHintMaxLength)})
+	}
+	return command, issues
+}
+
+// DiscordOptionType is the type of a Discord application command option
+type DiscordOptionType int
+
+// Discord application command option types
+const (
+	DiscordOptionSubCommand      DiscordOptionType = 1
+	DiscordOptionSubCommandGroup DiscordOptionType = 2
+	DiscordOptionString          DiscordOptionType = 3
+	DiscordOptionInteger         DiscordOptionType = 4
+	DiscordOptionBoolean         DiscordOptionType = 5
+	DiscordOptionNumber          DiscordOptionType = 10
+)
+
+// discordChatInput is the application command type of slash commands
+const discordChatInput = 1
+
+const (
+	discordNameMaxLength        = 32
+	discordDescriptionMaxLength = 100
+	discordMaxOptions           = 25
+	discordMaxChoices           = 25
+)
+
+var discordNamePattern = regexp.MustCompile(`^[-_\p{L}\p{N}]{1,32}$`)
+
+// DiscordCommand is the json Discord expects when registering an application command
+type DiscordCommand struct {
+	Name        string          `json:"name"`
+	Type        int             `json:"type"`
+	Description string          `json:"description"`
+	Options     []DiscordOption `json:"options,omitempty"`
+}
+
+// DiscordOption is an option of a Discord application command: a sub command, a group of sub commands or a value
+type DiscordOption struct {
+	Type        DiscordOptionType `json:"type"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Required    bool              `json:"required,omitempty"`
+	Choices     []DiscordChoice   `json:"choices,omitempty"`
+	Options     []DiscordOption   `json:"options,omitempty"`
+}
+
+// DiscordChoice is a value a Discord option accepts
+type DiscordChoice struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// GetDiscordCommand converts the slash command into a Discord application command. Names are lower cased
+// as Discord requires; the Discord adapter matches them back to the definition. Parts Discord can't represent,
+// such as commands nested deeper than a sub command group or commands with both arguments and sub commands,
+// are left out and reported.
+func (s *SlashCommand) GetDiscordCommand() (DiscordCommand, []ExportIssue) {
+	var issues []ExportIssue
+
+	command := DiscordCommand{
+		Name:        discordName(s.Name, s.Name, &issues),
+		Type:        discordChatInput,
+		Description: discordDescription(s.Description, s.Name, &issues),
+	}
+	command.Options = discordOptions(s.asSubCommand(), s.Name, 0, &issues)
+	return command, issues
+}
+
+// discordOptions converts the arguments or sub commands of a command. depth is 0 for the slash command,
+// 1 for a sub command and 2 for a sub sub command.
+func discordOptions(command SubCommand, path string, depth int, issues *[]ExportIssue) []DiscordOption {
+	var options []DiscordOption
+
+	arguments := visibleArguments(command.Arguments)
+
+	//the built-in help can't sit next to arguments, so commands with arguments go without it
+	var subCommands []SubCommand
+	for _, subCommand := range command.SubCommands {
+		if !subCommand.Hidden && !(subCommand.builtIn && len(arguments) > 0) {
+			subCommands = append(subCommands, subCommand)
+		}
+	}
+
+	if len(subCommands) > 0 {
+		if len(arguments) > 0 {
+			*issues = append(*issues, ExportIssue{path, "Discord commands can't have both arguments and sub commands, the arguments were left out"})
+		}
+		if depth >= 2 {
+			*issues = append(*issues, ExportIssue{path, "Discord only allows sub commands two levels deep, the sub commands were left out"})
+			return nil
+		}
+
+		for _, subCommand := range subCommands {
+			subCommandPath := path + " " + subCommand.Name
+			option := DiscordOption{
+				Type:        DiscordOptionSubCommand,
+				Name:        discordName(subCommand.Name, subCommandPath, issues),
+				Description: discordDescription(subCommand.Description, subCommandPath, issues),
+				Options:     discordOptions(subCommand, subCommandPath, depth+1, issues),
+			}
+			if len(option.Options) > 0 && option.Options[0].Type == DiscordOptionSubCommand {
+				option.Type = DiscordOptionSubCommandGroup
+			}
+			options = append(options, option)
+		}
+	} else {
+		//Discord wants required options first
+		for _, required := range []bool{true, false} {
+			for _, arg := range arguments {
+				if arg.Required == required {
+					options = append(options, discordArgumentOption(arg, path, issues))
+				}
+			}
+		}
+	}
+
+	if len(options) > discordMaxOptions {
+		*issues = append(*issues, ExportIssue{path, fmt.Sprintf("Discord allows at most %d options, the rest were left out", discordMaxOptions)})
+		options = options[:discordMaxOptions]
+	}
+	return options
+}
+
+func discordArgumentOption(arg Argument, path string, issues *[]ExportIssue) DiscordOption {
+	argPath := path + " --" + arg.Name
+	option := DiscordOption{
+		Type:        DiscordOptionString,
+		Name:        discordName(arg.Name, argPath, issues),
+		Description: discordDescription(arg.Description, argPath, issues),
+		Required:    arg.Required,
+	}
+	if arg.ArgType == "number" {
+		option.Type = DiscordOptionNumber
+	}
+
+	for _, choice := range arg.Choices {
+		var value interface{} = choice.Value
+		if option.Type == DiscordOptionNumber {
+			number, err := strconv.ParseFloat(choice.Value, 64)
+			if err != nil {
+				*issues = append(*issues, ExportIssue{argPath, fmt.Sprintf("choice %s is not a number", choice.Value)})
+				continue
+			}
+			value = number
+		}
+		option.Choices = append(option.Choices, DiscordChoice{Name: choice.Value, Value: value})
+	}
+	if len(option.Choices) > discordMaxChoices {
+		*issues = append(*issues, ExportIssue{argPath, fmt.Sprintf("Discord allows at most %d choices, the rest were left out", discordMaxChoices)})
+		option.Choices = option.Choices[:discordMaxChoices]
+	}
+	return option
+}
+
+func discordName(name string, path string, issues *[]ExportIssue) string {
+	discordName := strings.ToLower(name)
+	if !discordNamePattern.MatchString(discordName) {
+		*issues = append(*issues, ExportIssue{path, fmt.Sprintf("Discord names must be 1 to %d letters, numbers, - or _", discordNameMaxLength)})
+	}
+	return discordName
+}
+
+func discordDescription(description string, path string, issues *[]ExportIssue) string {
+	if description == "" {
+		*issues = append(*issues, ExportIssue{path, "Discord requires a description"})
+		return "-"
+	}
+	if utf8.RuneCountInString(description) > discordDescriptionMaxLength {
+		*issues = append(*issues, ExportIssue{path, fmt.Sprintf("description was cut to %d characters", discordDescriptionMaxLength)})
+		return truncate(description, discordDescriptionMaxLength)
+	}
+	return description
+}
+
+// truncate cuts text to at most length characters, ending with ... when cut
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length-3]) + "..."
+}
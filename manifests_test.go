@@ -0,0 +1,146 @@
+package slashparse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSlackCommand(t *testing.T) {
+	newSlash, _ := NewSlashCommand(todoDef)
+	got, issues := newSlash.GetSlackCommand("https://example.com/slack/todo")
+
+	assert.Equal(t, SlackCommand{
+		Command:     "/todo",
+		URL:         "https://example.com/slack/todo",
+		Description: "Keep track of things to do",
+		UsageHint:   "[add|list|done|assign|help]",
+	}, got)
+	assert.Empty(t, issues)
+}
+
+func TestGetSlackCommandIssues(t *testing.T) {
+	newSlash, _ := NewSlashCommand([]byte(`
+name: averyveryveryverylongslashcommandname
+description: ` + strings.Repeat("a", 2001)))
+	got, issues := newSlash.GetSlackCommand("https://example.com")
+
+	assert.Len(t, got.Description, 2000)
+	assert.Equal(t, []ExportIssue{
+		{"averyveryveryverylongslashcommandname", "Slack commands can be at most 32 characters"},
+		{"averyveryveryverylongslashcommandname", "description was cut to 2000 characters"},
+	}, issues)
+}
+
+func TestGetDiscordCommand(t *testing.T) {
+	newSlash, _ := NewSlashCommand(todoDef)
+	got, issues := newSlash.GetDiscordCommand()
+
+	assert.Empty(t, issues)
+	assert.Equal(t, "todo", got.Name)
+	assert.Equal(t, 1, got.Type)
+	assert.Len(t, got.Options, 5)
+
+	assert.Equal(t, DiscordOption{
+		Type:        DiscordOptionSubCommand,
+		Name:        "list",
+		Description: "List your todos",
+		Options: []DiscordOption{
+			{
+				Type:        DiscordOptionString,
+				Name:        "status",
+				Description: "Which todos to list",
+				Choices: []DiscordChoice{
+					{Name: "open", Value: "open"},
+					{Name: "done", Value: "done"},
+					{Name: "all", Value: "all"},
+				},
+			},
+		},
+	}, got.Options[1])
+
+	assign := got.Options[3]
+	assert.Equal(t, DiscordOptionNumber, assign.Options[0].Type)
+	assert.True(t, assign.Options[0].Required)
+	assert.Equal(t, "user", assign.Options[1].Name)
+}
+
+func TestGetDiscordCommandGroups(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+	got, _ := newSlash.GetDiscordCommand()
+
+	assert.Equal(t, DiscordOptionSubCommand, got.Options[0].Type)
+
+	move := got.Options[1]
+	assert.Equal(t, DiscordOptionSubCommandGroup, move.Type)
+	assert.Equal(t, "thread", move.Options[0].Name)
+	assert.Equal(t, DiscordOptionSubCommand, move.Options[0].Type)
+	assert.Equal(t, "messageid", move.Options[0].Options[0].Name)
+	assert.Equal(t, "channelid", move.Options[0].Options[1].Name)
+}
+
+func TestGetDiscordCommandIssues(t *testing.T) {
+	tests := []struct {
+		name string
+		def  string
+		want []ExportIssue
+	}{
+		{
+			name: "arguments and sub commands",
+			def: `
+name: print
+description: Print text
+arguments:
+  - name: text
+    argtype: text
+    description: the text
+subcommands:
+  - name: reverse
+    description: Print text backwards`,
+			want: []ExportIssue{{"print", "Discord commands can't have both arguments and sub commands, the arguments were left out"}},
+		},
+		{
+			name: "invalid names and descriptions",
+			def: `
+name: print
+description: Print text
+subcommands:
+  - name: two words
+  - name: long
+    description: ` + strings.Repeat("a", 101),
+			want: []ExportIssue{
+				{"print two words", "Discord names must be 1 to 32 letters, numbers, - or _"},
+				{"print two words", "Discord requires a description"},
+				{"print long", "description was cut to 100 characters"},
+			},
+		},
+		{
+			name: "number choices",
+			def: `
+name: roll
+description: Roll dice
+arguments:
+  - name: sides
+    argtype: number
+    description: sides of the dice
+    choices:
+      - value: "6"
+      - value: many`,
+			want: []ExportIssue{{"roll --sides", "choice many is not a number"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newSlash, _ := NewSlashCommand([]byte(test.def))
+			_, got := newSlash.GetDiscordCommand()
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestExportIssueString(t *testing.T) {
+	issue := ExportIssue{"todo list", "Discord requires a description"}
+	assert.Equal(t, "/todo list: Discord requires a description", issue.String())
+}
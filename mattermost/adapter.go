@@ -0,0 +1,162 @@
+// Package mattermost runs a slashparse SlashCommand in a Mattermost plugin.
+//
+// The package doesn't depend on the Mattermost server module. Its types mirror the model types
+// with the same json tags, and API is the part of plugin.API the adapter needs, so a plugin wires it
+// up with a small wrapper that converts the types with json.
+package mattermost
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ericjaystevens/slashparse"
+)
+
+// API is the part of Mattermost's plugin.API the adapter uses
+type API interface {
+	RegisterCommand(command *Command) error
+	UnregisterCommand(teamID, trigger string) error
+}
+
+// Adapter registers a slash command with Mattermost and executes it
+type Adapter struct {
+	// Command is the slash command that is registered and executed
+	Command *slashparse.SlashCommand
+	// API registers the command
+	API API
+	// ActionURL is the integration URL of buttons that don't have a URL of their own,
+	// such as /plugins/com.example.todo/action. Those buttons are left out when it is empty.
+	ActionURL string
+}
+
+// NewAdapter creates an Adapter for command
+func NewAdapter(command *slashparse.SlashCommand, api API) *Adapter {
+	return &Adapter{
+		Command: command,
+		API:     api,
+	}
+}
+
+// OnActivate registers the command, with its autocomplete data. Call it from the plugin's OnActivate.
+func (a *Adapter) OnActivate() error {
+	if a.Command == nil || a.API == nil {
+		return errors.New("the mattermost adapter needs a command and an API")
+	}
+	return a.API.RegisterCommand(a.GetCommand())
+}
+
+// OnDeactivate unregisters the command. Call it from the plugin's OnDeactivate.
+func (a *Adapter) OnDeactivate() error {
+	return a.API.UnregisterCommand("", strings.ToLower(a.Command.Name))
+}
+
+// GetCommand returns the command a plugin registers. A hidden command is registered without autocomplete.
+func (a *Adapter) GetCommand() *Command {
+	command := &Command{
+		Trigger:     strings.ToLower(a.Command.Name),
+		DisplayName: a.Command.Name,
+		Description: a.Command.Description,
+	}
+
+	autocompleteData := a.Command.GetAutocompleteData()
+	if autocompleteData != nil {
+		command.AutoComplete = true
+		command.AutoCompleteDesc = a.Command.Description
+		command.AutoCompleteHint = autocompleteData.Hint
+		command.AutocompleteData = autocompleteData
+	}
+	return command
+}
+
+// ExecuteCommand runs the command from a plugin's ExecuteCommand hook. The response always has text for the user,
+// an ephemeral error message when the command failed; the error is returned as well for logging.
+func (a *Adapter) ExecuteCommand(ctx context.Context, args *CommandArgs) (*CommandResponse, error) {
+	response, err := a.Command.ExecuteContext(ctx, NewRequest(args))
+	if err != nil && response.Text == "" {
+		response = slashparse.Response{Text: err.Error(), Visibility: slashparse.VisibilityEphemeral}
+	}
+	return a.NewCommandResponse(response), err
+}
+
+// NewRequest converts command args into a slashparse request. The root, parent and trigger IDs and the site URL
+// are kept in Metadata as rootID, parentID, triggerID and siteURL.
+func NewRequest(args *CommandArgs) slashparse.Request {
+	req := slashparse.Request{
+		UserID:    args.UserId,
+		ChannelID: args.ChannelId,
+		TeamID:    args.TeamId,
+		Platform:  "mattermost",
+		Text:      args.Command,
+		Metadata:  make(map[string]string),
+	}
+
+	for key, value := range map[string]string{
+		"rootID":    args.RootId,
+		"parentID":  args.ParentId,
+		"triggerID": args.TriggerId,
+		"siteURL":   args.SiteURL,
+	} {
+		if value != "" {
+			req.Metadata[key] = value
+		}
+	}
+	return req
+}
+
+// NewCommandResponse converts a response into a command response. Actions become buttons in an extra attachment,
+// the follow-up URL becomes the goto location and the metadata becomes the props of the post.
+func (a *Adapter) NewCommandResponse(response slashparse.Response) *CommandResponse {
+	commandResponse := &CommandResponse{
+		ResponseType: CommandResponseTypeEphemeral,
+		Text:         response.Text,
+		GotoLocation: response.FollowUpURL,
+		Props:        response.Metadata,
+	}
+	if response.Visibility == slashparse.VisibilityInChannel {
+		commandResponse.ResponseType = CommandResponseTypeInChannel
+	}
+
+	for _, attachment := range response.Attachments {
+		slackAttachment := &SlackAttachment{
+			Color:     attachment.Color,
+			Title:     attachment.Title,
+			TitleLink: attachment.TitleLink,
+			Text:      attachment.Text,
+			ImageURL:  attachment.ImageURL,
+		}
+		for _, field := range attachment.Fields {
+			slackAttachment.Fields = append(slackAttachment.Fields, &SlackAttachmentField{
+				Title: field.Title,
+				Value: field.Value,
+				Short: field.Short,
+			})
+		}
+		commandResponse.Attachments = append(commandResponse.Attachments, slackAttachment)
+	}
+
+	var actions []*PostAction
+	for _, action := range response.Actions {
+		url := action.URL
+		if url == "" {
+			url = a.ActionURL
+		}
+		if url == "" {
+			continue
+		}
+		actions = append(actions, &PostAction{
+			Id:    action.ID,
+			Type:  "button",
+			Name:  action.Text,
+			Style: action.Style,
+			Integration: &PostActionIntegration{
+				URL:     url,
+				Context: map[string]interface{}{"action": action.ID, "value": action.Value},
+			},
+		})
+	}
+	if len(actions) > 0 {
+		commandResponse.Attachments = append(commandResponse.Attachments, &SlackAttachment{Actions: actions})
+	}
+	return commandResponse
+}
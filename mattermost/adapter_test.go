@@ -0,0 +1,120 @@
+package mattermost
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ericjaystevens/slashparse"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAdapter(t *testing.T) (*Adapter, *FakeAPI) {
+	def, err := ioutil.ReadFile("../testData/todo.yaml")
+	assert.NoError(t, err)
+	command, err := slashparse.NewSlashCommand(def)
+	assert.NoError(t, err)
+
+	command.SetContextHandler("todo add", func(ctx context.Context, req slashparse.Request, values map[string]string) (slashparse.Response, error) {
+		return slashparse.Response{
+			Text:        req.UserID + " added " + values["message"] + " in " + req.ChannelID,
+			Visibility:  slashparse.VisibilityInChannel,
+			Attachments: []slashparse.Attachment{{Title: "Todo", Fields: []slashparse.AttachmentField{{Title: "Root", Value: req.Metadata["rootID"]}}}},
+			Actions: []slashparse.Action{
+				{ID: "undo", Text: "Undo", Value: "1"},
+				{ID: "open", Text: "Open", URL: "https://example.com/todo/1"},
+			},
+			FollowUpURL: "/team/channels/todo",
+		}, nil
+	})
+
+	api := NewFakeAPI()
+	return NewAdapter(&command, api), api
+}
+
+func TestOnActivate(t *testing.T) {
+	adapter, api := newTestAdapter(t)
+
+	assert.NoError(t, adapter.OnActivate())
+	command := api.Commands["todo"]
+	assert.Equal(t, "todo", command.Trigger)
+	assert.True(t, command.AutoComplete)
+	assert.Equal(t, "Keep track of things to do", command.AutoCompleteDesc)
+	assert.Equal(t, "[command]", command.AutoCompleteHint)
+	assert.Equal(t, "add", command.AutocompleteData.SubCommands[0].Trigger)
+
+	assert.NoError(t, adapter.OnDeactivate())
+	assert.Empty(t, api.Commands)
+
+	api.Err = errors.New("server is down")
+	assert.EqualError(t, adapter.OnActivate(), "server is down")
+	assert.Error(t, (&Adapter{}).OnActivate())
+}
+
+func TestGetCommandHidden(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+	adapter.Command.Hidden = true
+
+	command := adapter.GetCommand()
+	assert.Equal(t, "todo", command.Trigger)
+	assert.False(t, command.AutoComplete)
+	assert.Nil(t, command.AutocompleteData)
+}
+
+func TestExecuteCommand(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+	adapter.ActionURL = "/plugins/com.example.todo/action"
+
+	got, err := adapter.ExecuteCommand(context.Background(), &CommandArgs{
+		UserId:    "user1",
+		ChannelId: "channel1",
+		TeamId:    "team1",
+		RootId:    "post1",
+		Command:   "/todo add buy milk",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &CommandResponse{
+		ResponseType: CommandResponseTypeInChannel,
+		Text:         "user1 added buy milk in channel1",
+		GotoLocation: "/team/channels/todo",
+		Attachments: []*SlackAttachment{
+			{Title: "Todo", Fields: []*SlackAttachmentField{{Title: "Root", Value: "post1"}}},
+			{Actions: []*PostAction{
+				{
+					Id:          "undo",
+					Type:        "button",
+					Name:        "Undo",
+					Integration: &PostActionIntegration{URL: "/plugins/com.example.todo/action", Context: map[string]interface{}{"action": "undo", "value": "1"}},
+				},
+				{
+					Id:          "open",
+					Type:        "button",
+					Name:        "Open",
+					Integration: &PostActionIntegration{URL: "https://example.com/todo/1", Context: map[string]interface{}{"action": "open", "value": ""}},
+				},
+			}},
+		},
+	}, got)
+}
+
+func TestExecuteCommandErrors(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+
+	got, err := adapter.ExecuteCommand(context.Background(), &CommandArgs{Command: "/todo done"})
+	assert.Error(t, err)
+	assert.Equal(t, CommandResponseTypeEphemeral, got.ResponseType)
+	assert.Equal(t, "required field id is missing, see /todo help for more details", got.Text)
+
+	got, err = adapter.ExecuteCommand(context.Background(), &CommandArgs{Command: "/todo help list"})
+	assert.NoError(t, err)
+	assert.Equal(t, CommandResponseTypeEphemeral, got.ResponseType)
+	assert.Contains(t, got.Text, "/todo list [status]")
+}
+
+func TestNewCommandResponseWithoutActionURL(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+
+	got := adapter.NewCommandResponse(slashparse.Response{Text: "done", Actions: []slashparse.Action{{ID: "undo", Text: "Undo"}}})
+	assert.Equal(t, &CommandResponse{ResponseType: CommandResponseTypeEphemeral, Text: "done"}, got)
+}
@@ -0,0 +1,43 @@
+package mattermost
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FakeAPI is an in memory API for testing plugins without a Mattermost server
+type FakeAPI struct {
+	// Commands are the registered commands by trigger
+	Commands map[string]*Command
+	// Err is returned by every call when set
+	Err error
+}
+
+// NewFakeAPI creates a FakeAPI without commands
+func NewFakeAPI() *FakeAPI {
+	return &FakeAPI{Commands: make(map[string]*Command)}
+}
+
+// RegisterCommand stores the command by its trigger
+func (f *FakeAPI) RegisterCommand(command *Command) error {
+	if f.Err != nil {
+		return f.Err
+	}
+	if command.Trigger == "" || strings.HasPrefix(command.Trigger, "/") || strings.Contains(command.Trigger, " ") {
+		return fmt.Errorf("invalid trigger %q", command.Trigger)
+	}
+	f.Commands[command.Trigger] = command
+	return nil
+}
+
+// UnregisterCommand removes the command with trigger
+func (f *FakeAPI) UnregisterCommand(teamID, trigger string) error {
+	if f.Err != nil {
+		return f.Err
+	}
+	if _, ok := f.Commands[trigger]; !ok {
+		return fmt.Errorf("command %s is not registered", trigger)
+	}
+	delete(f.Commands, trigger)
+	return nil
+}
@@ -0,0 +1,80 @@
+package mattermost
+
+import "github.com/ericjaystevens/slashparse"
+
+// Response types of a CommandResponse
+const (
+	CommandResponseTypeInChannel = "in_channel"
+	CommandResponseTypeEphemeral = "ephemeral"
+)
+
+// Command mirrors the fields of Mattermost's model.Command a plugin sets when registering a command.
+// The json tags match, so it can be converted with json.
+type Command struct {
+	Trigger          string                       `json:"trigger"`
+	AutoComplete     bool                         `json:"auto_complete"`
+	AutoCompleteDesc string                       `json:"auto_complete_desc"`
+	AutoCompleteHint string                       `json:"auto_complete_hint"`
+	DisplayName      string                       `json:"display_name"`
+	Description      string                       `json:"description"`
+	AutocompleteData *slashparse.AutocompleteData `json:"autocomplete_data,omitempty"`
+}
+
+// CommandArgs mirrors Mattermost's model.CommandArgs
+type CommandArgs struct {
+	UserId    string `json:"user_id"`
+	ChannelId string `json:"channel_id"`
+	TeamId    string `json:"team_id"`
+	RootId    string `json:"root_id"`
+	ParentId  string `json:"parent_id"`
+	TriggerId string `json:"trigger_id,omitempty"`
+	Command   string `json:"command"`
+	SiteURL   string `json:"-"`
+}
+
+// CommandResponse mirrors Mattermost's model.CommandResponse
+type CommandResponse struct {
+	ResponseType string                 `json:"response_type"`
+	Text         string                 `json:"text"`
+	Username     string                 `json:"username"`
+	ChannelId    string                 `json:"channel_id"`
+	IconURL      string                 `json:"icon_url"`
+	Type         string                 `json:"type"`
+	Props        map[string]interface{} `json:"props"`
+	GotoLocation string                 `json:"goto_location"`
+	TriggerId    string                 `json:"trigger_id"`
+	Attachments  []*SlackAttachment     `json:"attachments"`
+}
+
+// SlackAttachment mirrors Mattermost's model.SlackAttachment
+type SlackAttachment struct {
+	Color     string                  `json:"color"`
+	Title     string                  `json:"title"`
+	TitleLink string                  `json:"title_link"`
+	Text      string                  `json:"text"`
+	Fields    []*SlackAttachmentField `json:"fields"`
+	ImageURL  string                  `json:"image_url"`
+	Actions   []*PostAction           `json:"actions,omitempty"`
+}
+
+// SlackAttachmentField mirrors Mattermost's model.SlackAttachmentField
+type SlackAttachmentField struct {
+	Title string      `json:"title"`
+	Value interface{} `json:"value"`
+	Short bool        `json:"short"`
+}
+
+// PostAction mirrors Mattermost's model.PostAction for buttons
+type PostAction struct {
+	Id          string                 `json:"id,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	Style       string                 `json:"style,omitempty"`
+	Integration *PostActionIntegration `json:"integration,omitempty"`
+}
+
+// PostActionIntegration mirrors Mattermost's model.PostActionIntegration
+type PostActionIntegration struct {
+	URL     string                 `json:"url,omitempty"`
+	Context map[string]interface{} `json:"context,omitempty"`
+}
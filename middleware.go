@@ -0,0 +1,72 @@
+package slashparse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Middleware wraps a handler to run code before or after it. Middleware can short-circuit
+// by returning a response or error without calling next. The command path being run is
+// available as req.CommandPath.
+type Middleware func(next Handler) Handler
+
+// Use adds middleware that runs for every command, in the order given
+func (s *SlashCommand) Use(middleware ...Middleware) {
+	s.middleware = append(s.middleware, middleware...)
+}
+
+// Use adds middleware that runs for this sub command and its sub commands
+func (s *SubCommand) Use(middleware ...Middleware) {
+	s.middleware = append(s.middleware, middleware...)
+}
+
+// UseFor adds middleware to the command found at commandString and everything below it
+func (s *SlashCommand) UseFor(commandString string, middleware ...Middleware) error {
+	if strings.EqualFold(commandString, s.Name) {
+		s.Use(middleware...)
+		return nil
+	}
+
+	for i, subCommand := range s.SubCommands {
+		if strings.EqualFold(commandString, subCommand.getCommandPath()) {
+			s.SubCommands[i].Use(middleware...)
+			return nil
+		}
+
+		for j, subSubCommand := range subCommand.SubCommands {
+			if strings.EqualFold(commandString, subSubCommand.getCommandPath()) {
+				s.SubCommands[i].SubCommands[j].Use(middleware...)
+				return nil
+			}
+		}
+	}
+	return errors.New("Unable to find mathing subcommand")
+}
+
+// applyMiddleware wraps handler with the slash command middleware followed by the middleware of
+// each sub command along the path, so the outermost command's middleware runs first
+func (s *SlashCommand) applyMiddleware(commandString string, handler Handler) Handler {
+	chain := append([]Middleware{}, s.middleware...)
+	for _, subCommand := range s.getSubCommandChain(commandString) {
+		chain = append(chain, subCommand.middleware...)
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		handler = chain[i](handler)
+	}
+	return handler
+}
+
+// Recover is middleware that turns a panic in a handler into an error
+func Recover(next Handler) Handler {
+	return func(ctx context.Context, req Request, values map[string]string) (response Response, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("/%s failed: %v", req.CommandPath, r)
+			}
+		}()
+		return next(ctx, req, values)
+	}
+}
@@ -0,0 +1,104 @@
+package slashparse
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordMiddleware appends name to calls before and after running the handler
+func recordMiddleware(name string, calls *[]string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req Request, values map[string]string) (Response, error) {
+			*calls = append(*calls, name+" before "+req.CommandPath)
+			response, err := next(ctx, req, values)
+			*calls = append(*calls, name+" after")
+			return response, err
+		}
+	}
+}
+
+type middlewareTests struct {
+	name          string
+	commandString string
+	wantCalls     []string
+	want          string
+}
+
+func TestMiddleware(t *testing.T) {
+
+	tests := []middlewareTests{
+		{
+			name:          "slash command middleware runs for sub sub commands",
+			commandString: "/wrangler copy thread abc",
+			wantCalls:     []string{"global before wrangler copy thread", "global after"},
+			want:          "copied abc",
+		},
+		{
+			name:          "sub command middleware runs inside slash command middleware",
+			commandString: "/wrangler move thread abc",
+			wantCalls:     []string{"global before wrangler move thread", "move before wrangler move thread", "move after", "global after"},
+			want:          "moved abc",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls []string
+			newSlash, _ := NewSlashCommand(wranglerDef)
+			_ = newSlash.SetHandler("wrangler move thread", func(values map[string]string) (string, error) {
+				return "moved " + values["messageID"], nil
+			})
+			_ = newSlash.SetHandler("wrangler copy thread", func(values map[string]string) (string, error) {
+				return "copied " + values["messageID"], nil
+			})
+			newSlash.Use(recordMiddleware("global", &calls))
+			err := newSlash.UseFor("wrangler move", recordMiddleware("move", &calls))
+			assert.Nil(t, err)
+
+			got, err := newSlash.Execute(test.commandString)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantCalls, calls)
+		})
+	}
+
+	t.Run("middleware can short circuit", func(t *testing.T) {
+		newSlash, _ := NewSlashCommand(wranglerDef)
+		_ = newSlash.SetHandler("wrangler info", func(values map[string]string) (string, error) {
+			return "", errors.New("handler should not be called")
+		})
+		newSlash.Use(func(next Handler) Handler {
+			return func(ctx context.Context, req Request, values map[string]string) (Response, error) {
+				return Response{Text: "not today", Visibility: VisibilityEphemeral}, nil
+			}
+		})
+
+		got, err := newSlash.ExecuteContext(context.Background(), Request{Text: "/wrangler info"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, Response{Text: "not today", Visibility: VisibilityEphemeral}, got)
+	})
+
+	t.Run("unknown command path", func(t *testing.T) {
+		newSlash, _ := NewSlashCommand(wranglerDef)
+		err := newSlash.UseFor("wrangler juggle", Recover)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestRecover(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+	_ = newSlash.SetHandler("wrangler info", func(values map[string]string) (string, error) {
+		panic("boom")
+	})
+	newSlash.Use(Recover)
+
+	_, err := newSlash.Execute("/wrangler info")
+
+	assert.EqualError(t, err, "/wrangler info failed: boom")
+}
@@ -0,0 +1,187 @@
+// Package repl is an interactive prompt for trying out a slash command definition without deploying it
+package repl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ericjaystevens/slashparse"
+	"golang.org/x/term"
+)
+
+// DefaultPrompt is shown before each line when Prompt is empty
+const DefaultPrompt = "> "
+
+// REPL reads commands, shows how they parse and runs them
+type REPL struct {
+	// Command is the slash command lines are run with. The first line adds middleware to it that shows what
+	// each line the REPL runs parsed into, commands run some other way aren't affected.
+	Command *slashparse.SlashCommand
+	// Prompt is shown before each line, DefaultPrompt when empty
+	Prompt string
+	// Request is the request lines are run as, its Text is set to each line
+	Request slashparse.Request
+
+	// echoing is the command echoValues was added to
+	echoing *slashparse.SlashCommand
+}
+
+// echoKey is the context key of the writer echoValues writes to
+type echoKey struct{}
+
+// New creates a REPL for command
+func New(command *slashparse.SlashCommand) *REPL {
+	return &REPL{
+		Command: command,
+		Request: slashparse.Request{Platform: "repl"},
+	}
+}
+
+// StubHandlers sets a handler on every command path that answers with the path and values it received,
+// so a definition can be tried before its handlers are written
+func StubHandlers(command *slashparse.SlashCommand) {
+	for _, path := range command.CommandPaths() {
+		path := path
+		command.SetContextHandler(path, func(ctx context.Context, req slashparse.Request, values map[string]string) (slashparse.Response, error) {
+			return slashparse.TextResponse("ran /" + path + " with " + formatValues(values)), nil
+		})
+	}
+}
+
+// Run reads lines from rw until exit, quit or the end of input. The slash command name can be left out of lines,
+// as in "move thread 1234". Tab completes the token under the cursor, listing the candidates when there are several.
+func (r *REPL) Run(rw io.ReadWriter) error {
+	prompt := r.Prompt
+	if prompt == "" {
+		prompt = DefaultPrompt
+	}
+
+	terminal := term.NewTerminal(rw, prompt)
+	terminal.AutoCompleteCallback = func(line string, pos int, key rune) (string, int, bool) {
+		if key != '\t' {
+			return "", 0, false
+		}
+		return r.complete(terminal, line, pos)
+	}
+
+	fmt.Fprintf(terminal, "/%s - %s\nType help for help, exit to quit.\n", r.Command.Name, r.Command.Description)
+	for {
+		line, err := terminal.ReadLine()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		line = strings.TrimSpace(line)
+		switch line {
+		case "":
+			continue
+		case "exit", "quit":
+			return nil
+		}
+		r.runLine(terminal, line)
+	}
+}
+
+// runLine runs a line, showing the command path and values it parsed into before the handler runs
+func (r *REPL) runLine(w io.Writer, line string) {
+	if r.echoing != r.Command {
+		r.Command.Use(echoValues)
+		r.echoing = r.Command
+	}
+
+	req := r.Request
+	req.Text = r.slashString(line)
+
+	response, err := r.Command.ExecuteContext(context.WithValue(context.Background(), echoKey{}, w), req)
+	if response.Text != "" {
+		fmt.Fprintln(w, response.Text)
+	} else if err != nil {
+		fmt.Fprintln(w, err)
+	}
+}
+
+// echoValues is middleware that writes the command path and values of the command being run to the writer in
+// ctx, so the values shown are the ones the handler gets
+func echoValues(next slashparse.Handler) slashparse.Handler {
+	return func(ctx context.Context, req slashparse.Request, values map[string]string) (slashparse.Response, error) {
+		if w, ok := ctx.Value(echoKey{}).(io.Writer); ok {
+			fmt.Fprintf(w, "path: %s\nvalues: %s\n", req.CommandPath, formatValues(values))
+		}
+		return next(ctx, req, values)
+	}
+}
+
+// complete completes the token under the cursor, writing the candidates when there is more than one
+func (r *REPL) complete(w io.Writer, line string, pos int) (string, int, bool) {
+	slashString := r.slashString(line)
+	offset := len(slashString) - len(line)
+
+	completions, err := r.Command.Complete(slashString, pos+offset)
+	if err != nil || len(completions) == 0 {
+		return "", 0, false
+	}
+
+	start := strings.LastIndex(line[:pos], " ") + 1
+	prefix := line[start:pos]
+
+	value := completions[0].Value
+	if len(completions) == 1 {
+		value += " "
+	} else {
+		for _, completion := range completions[1:] {
+			value = commonPrefix(value, completion.Value)
+		}
+		if len(value) <= len(prefix) {
+			fmt.Fprintln(w, formatCompletions(completions))
+			return "", 0, false
+		}
+	}
+
+	return line[:start] + value + line[pos:], start + len(value), true
+}
+
+// slashString adds the slash command name to lines that leave it out
+func (r *REPL) slashString(line string) string {
+	if strings.HasPrefix(line, "/") {
+		return line
+	}
+	return "/" + r.Command.Name + " " + line
+}
+
+func formatValues(values map[string]string) string {
+	data, _ := json.Marshal(values)
+	return string(data)
+}
+
+func formatCompletions(completions []slashparse.Completion) string {
+	width := 0
+	for _, completion := range completions {
+		if len(completion.Value) > width {
+			width = len(completion.Value)
+		}
+	}
+
+	lines := make([]string, 0, len(completions))
+	for _, completion := range completions {
+		lines = append(lines, strings.TrimRight(fmt.Sprintf("  %-*s  %s", width, completion.Value, completion.Description), " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func commonPrefix(a string, b string) string {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[:i]
+		}
+	}
+	if len(a) < len(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,124 @@
+package repl
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/ericjaystevens/slashparse"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTerminal struct {
+	io.Reader
+	output bytes.Buffer
+}
+
+func (f *fakeTerminal) Write(p []byte) (int, error) {
+	return f.output.Write(p)
+}
+
+func newTestREPL(t *testing.T) *REPL {
+	def, err := ioutil.ReadFile("../testData/todo.yaml")
+	assert.NoError(t, err)
+	command, err := slashparse.NewSlashCommand(def)
+	assert.NoError(t, err)
+	StubHandlers(&command)
+	return New(&command)
+}
+
+func run(t *testing.T, r *REPL, input string) string {
+	terminal := &fakeTerminal{Reader: strings.NewReader(input)}
+	assert.NoError(t, r.Run(terminal))
+	return terminal.output.String()
+}
+
+func TestRun(t *testing.T) {
+	got := run(t, newTestREPL(t), "/todo add buy milk\rlist --status done\rdone\rexit\rlist\r")
+
+	assert.Contains(t, got, "/todo - Keep track of things to do")
+	assert.Contains(t, got, "path: todo add\r\nvalues: {\"message\":\"buy milk\"}\r\nran /todo add with {\"message\":\"buy milk\"}")
+	assert.Contains(t, got, "path: todo list\r\nvalues: {\"status\":\"done\"}\r\nran /todo list with {\"status\":\"done\"}")
+	assert.Contains(t, got, "required field id is missing, see /todo help for more details")
+	assert.NotContains(t, got, "ran /todo list with {\"status\":\"open\"}")
+}
+
+func TestRunContextValues(t *testing.T) {
+	def, err := ioutil.ReadFile("../testData/wrangler.yaml")
+	assert.NoError(t, err)
+	command, err := slashparse.NewSlashCommand(def)
+	assert.NoError(t, err)
+	StubHandlers(&command)
+	r := New(&command)
+	r.Request.ChannelID = "town-square"
+
+	got := run(t, r, "move thread abc123\r")
+
+	assert.Contains(t, got, "path: wrangler move thread\r\nvalues: {\"channelID\":\"town-square\",\"messageID\":\"abc123\"}\r\n")
+	assert.Contains(t, got, "ran /wrangler move thread with {\"channelID\":\"town-square\",\"messageID\":\"abc123\"}")
+}
+
+func TestRunParsesOnce(t *testing.T) {
+	def, err := ioutil.ReadFile("../testData/defaults.yaml")
+	assert.NoError(t, err)
+	command, err := slashparse.NewSlashCommand(def)
+	assert.NoError(t, err)
+	StubHandlers(&command)
+	calls := 0
+	_ = command.RegisterDefaultFunc("tomorrow", func(contextValues map[string]string) (string, error) {
+		calls++
+		return fmt.Sprintf("call %d", calls), nil
+	})
+
+	got := run(t, New(&command), "lunch\r")
+
+	assert.Equal(t, 1, calls)
+	assert.Contains(t, got, "path: remind\r\nvalues: {\"message\":\"lunch\",\"timezone\":\"UTC\",\"when\":\"call 1\"}\r\n")
+	assert.Contains(t, got, "ran /remind with {\"message\":\"lunch\",\"timezone\":\"UTC\",\"when\":\"call 1\"}")
+}
+
+func TestRunHelp(t *testing.T) {
+	got := run(t, newTestREPL(t), "help list\r")
+	assert.Contains(t, got, "/todo list [status]")
+}
+
+func TestRunCompletion(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "single candidate",
+			input: "li\t--st\tdo\t\r",
+			want:  "ran /todo list with {\"status\":\"done\"}",
+		},
+		{
+			name:  "common prefix",
+			input: "/to\tlist -\t\r",
+			want:  "--status  Which todos to list",
+		},
+		{
+			name:  "list candidates",
+			input: "a\t\r",
+			want:  "add     Add a todo\r\n  assign  Assign a todo to someone",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := run(t, newTestREPL(t), test.input)
+			assert.Contains(t, got, test.want)
+		})
+	}
+}
+
+func TestStubHandlers(t *testing.T) {
+	r := newTestREPL(t)
+	got, err := r.Command.Execute("/todo done 12")
+	assert.NoError(t, err)
+	assert.Equal(t, "ran /todo done with {\"id\":\"12\"}", got)
+}
@@ -0,0 +1,55 @@
+package slashparse
+
+// Visibility controls who can see a response
+type Visibility string
+
+const (
+	// VisibilityDefault leaves the choice to the platform adapter
+	VisibilityDefault Visibility = ""
+	// VisibilityEphemeral shows the response only to the user that ran the command
+	VisibilityEphemeral Visibility = "ephemeral"
+	// VisibilityInChannel posts the response to everyone in the channel
+	VisibilityInChannel Visibility = "in_channel"
+)
+
+// Response is what a handler sends back to the user. Platform adapters translate it into
+// their own message format and ignore the parts their platform can't show.
+type Response struct {
+	Text        string                 `json:"text"`
+	Visibility  Visibility             `json:"visibility,omitempty"`
+	Attachments []Attachment           `json:"attachments,omitempty"`
+	Actions     []Action               `json:"actions,omitempty"`
+	FollowUpURL string                 `json:"followUpURL,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Attachment is a block of rich content shown under the response text
+type Attachment struct {
+	Title     string            `json:"title,omitempty"`
+	TitleLink string            `json:"titleLink,omitempty"`
+	Text      string            `json:"text,omitempty"`
+	Color     string            `json:"color,omitempty"`
+	ImageURL  string            `json:"imageURL,omitempty"`
+	Fields    []AttachmentField `json:"fields,omitempty"`
+}
+
+// AttachmentField is a titled value shown in an attachment
+type AttachmentField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short,omitempty"`
+}
+
+// Action is a button shown with the response. Either URL is opened or Value is sent back to the plugin.
+type Action struct {
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+	Style string `json:"style,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// TextResponse creates a response that only has text
+func TextResponse(text string) Response {
+	return Response{Text: text}
+}
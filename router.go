@@ -0,0 +1,117 @@
+package slashparse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Router dispatches to one of several slash commands by the name the text starts with, for plugins
+// and bots that own more than one slash command
+type Router struct {
+	commands []*SlashCommand
+	byName   map[string]*SlashCommand
+}
+
+// NewRouter creates a router for commands. It returns an error if two of them have the same name.
+func NewRouter(commands ...*SlashCommand) (*Router, error) {
+	router := &Router{byName: make(map[string]*SlashCommand)}
+	for _, command := range commands {
+		if err := router.Add(command); err != nil {
+			return nil, err
+		}
+	}
+	return router, nil
+}
+
+// Add registers a slash command. Names are matched without regard to case, so /Todo and /todo collide.
+func (r *Router) Add(command *SlashCommand) error {
+	if command == nil || command.Name == "" {
+		return errors.New("unable to add a slash command without a name")
+	}
+
+	name := strings.ToLower(command.Name)
+	if _, ok := r.byName[name]; ok {
+		return fmt.Errorf("/%s is already registered", name)
+	}
+	r.byName[name] = command
+	r.commands = append(r.commands, command)
+	return nil
+}
+
+// Command returns the slash command registered as name
+func (r *Router) Command(name string) (*SlashCommand, bool) {
+	command, ok := r.byName[strings.ToLower(strings.TrimPrefix(name, "/"))]
+	return command, ok
+}
+
+// Commands returns the registered slash commands in the order they were added
+func (r *Router) Commands() []*SlashCommand {
+	return append([]*SlashCommand(nil), r.commands...)
+}
+
+// Execute runs slashString with the slash command it starts with
+func (r *Router) Execute(slashString string) (string, error) {
+	response, err := r.ExecuteContext(context.Background(), Request{Text: slashString})
+	return response.Text, err
+}
+
+// ExecuteContext runs req with the slash command req.Text starts with. Text for a command that
+// isn't registered is answered with an ephemeral response as well as an error.
+func (r *Router) ExecuteContext(ctx context.Context, req Request) (Response, error) {
+	name := ""
+	if fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(req.Text), "/")); len(fields) > 0 {
+		name = fields[0]
+	}
+
+	command, ok := r.Command(name)
+	if !ok {
+		err := fmt.Errorf("/%s is not a valid command", name)
+		return Response{Text: err.Error(), Visibility: VisibilityEphemeral}, err
+	}
+	return command.ExecuteContext(ctx, req)
+}
+
+// GetHelp returns a markdown index of the registered slash commands that aren't hidden
+func (r *Router) GetHelp() (string, error) {
+	var index []HelpCommand
+	for _, command := range r.commands {
+		if command.Hidden {
+			continue
+		}
+		model, err := command.GetHelpModel(command.Name)
+		if err != nil {
+			return "", err
+		}
+		index = append(index, model)
+	}
+	return executeTextTemplate("indexHelp.tpl", indexHelpTemplateContent, index)
+}
+
+// GetAutocompleteData returns the Mattermost autocomplete data of every registered slash command that isn't hidden
+func (r *Router) GetAutocompleteData() []*AutocompleteData {
+	var data []*AutocompleteData
+	for _, command := range r.commands {
+		if command.Hidden {
+			continue
+		}
+		data = append(data, command.GetAutocompleteData())
+	}
+	return data
+}
+
+// Verify checks every registered slash command has a handler for each command that can be run
+func (r *Router) Verify() error {
+	var problems []string
+	for _, command := range r.commands {
+		if err := command.Verify(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package slashparse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRouter(t *testing.T) *Router {
+	todo, _ := NewSlashCommand(todoDef)
+	wrangler, _ := NewSlashCommand(wranglerDef)
+	_ = todo.SetHandler("todo add", func(values map[string]string) (string, error) {
+		return "added " + values["message"], nil
+	})
+	_ = wrangler.SetHandler("wrangler info", func(values map[string]string) (string, error) {
+		return "wrangler v1", nil
+	})
+
+	router, err := NewRouter(&todo, &wrangler)
+	assert.Nil(t, err)
+	return router
+}
+
+func TestRouterExecute(t *testing.T) {
+	tests := []struct {
+		name          string
+		commandString string
+		want          Response
+		wantErr       string
+	}{
+		{
+			name:          "first command",
+			commandString: "/todo add buy milk",
+			want:          TextResponse("added buy milk"),
+		},
+		{
+			name:          "second command",
+			commandString: "/wrangler info",
+			want:          TextResponse("wrangler v1"),
+		},
+		{
+			name:          "names ignore case",
+			commandString: "/Wrangler info",
+			want:          TextResponse("wrangler v1"),
+		},
+		{
+			name:          "errors come from the command",
+			commandString: "/todo remove 1",
+			want:          Response{Text: "/todo is not a valid command. Please see /todo help", Visibility: VisibilityEphemeral},
+			wantErr:       "/todo is not a valid command. Please see /todo help",
+		},
+		{
+			name:          "unknown command",
+			commandString: "/remind me later",
+			want:          Response{Text: "/remind is not a valid command", Visibility: VisibilityEphemeral},
+			wantErr:       "/remind is not a valid command",
+		},
+	}
+
+	router := newTestRouter(t)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := router.ExecuteContext(context.Background(), Request{Text: test.commandString})
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestRouterCollision(t *testing.T) {
+	todo, _ := NewSlashCommand(todoDef)
+	other, _ := NewSlashCommand(todoDef)
+	other.Name = "TODO"
+
+	_, err := NewRouter(&todo, &other)
+
+	assert.EqualError(t, err, "/todo is already registered")
+}
+
+func TestRouterGetHelp(t *testing.T) {
+	router := newTestRouter(t)
+
+	got, err := router.GetHelp()
+
+	want := "#### Available Commands\n\n" +
+		"* **/todo**: _Keep track of things to do_\n  `/todo help` for more details\n\n" +
+		"* **/wrangler**: _Manage Mattermost Messages Masterfully_\n  `/wrangler help` for more details\n"
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestRouterGetAutocompleteData(t *testing.T) {
+	router := newTestRouter(t)
+
+	got := router.GetAutocompleteData()
+
+	assert.Len(t, got, 2)
+	assert.Equal(t, "todo", got[0].Trigger)
+	assert.Equal(t, "wrangler", got[1].Trigger)
+}
+
+func TestRouterGetAutocompleteDataHidden(t *testing.T) {
+	router := newTestRouter(t)
+	wrangler, _ := router.Command("/wrangler")
+	wrangler.Hidden = true
+
+	got := router.GetAutocompleteData()
+
+	assert.Len(t, got, 1)
+	assert.Equal(t, "todo", got[0].Trigger)
+}
+
+func TestRouterVerify(t *testing.T) {
+	router := newTestRouter(t)
+	todo, _ := router.Command("/todo")
+	for _, path := range []string{"todo list", "todo done", "todo assign"} {
+		_ = todo.SetHandler(path, func(values map[string]string) (string, error) { return "", nil })
+	}
+
+	err := router.Verify()
+
+	assert.EqualError(t, err, "no handler set for /wrangler move thread, /wrangler copy thread, /wrangler attach message, /wrangler list channels, /wrangler list messages")
+}
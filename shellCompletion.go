@@ -0,0 +1,302 @@
+package slashparse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Shell names a shell completion scripts can be generated for
+type Shell string
+
+const (
+	// ShellBash is a bash completion script, loaded with source or from bash_completion.d
+	ShellBash Shell = "bash"
+	// ShellZsh is a zsh completion script, placed in a directory on $fpath as _name
+	ShellZsh Shell = "zsh"
+	// ShellFish is a fish completion script, placed in ~/.config/fish/completions as name.fish
+	ShellFish Shell = "fish"
+)
+
+// completionNode is a command in the tree with the path used to reach it from the shell
+type completionNode struct {
+	path    string
+	command SubCommand
+}
+
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// GetCompletionScript generates a completion script for running the slash command as a local CLI,
+// completing sub commands, flags and their short names, and choices
+func (s *SlashCommand) GetCompletionScript(shell Shell) (string, error) {
+	program := strings.ToLower(s.Name)
+	nodes := getCompletionNodes(program, s.asSubCommand())
+	function := "_" + nonIdentifier.ReplaceAllString(program, "_")
+
+	switch Shell(strings.ToLower(string(shell))) {
+	case ShellBash:
+		return bashCompletion(program, function, nodes), nil
+	case ShellZsh:
+		return zshCompletion(program, function, nodes), nil
+	case ShellFish:
+		return fishCompletion(program, function, nodes), nil
+	}
+	return "", fmt.Errorf("unknown shell %s", shell)
+}
+
+// getCompletionNodes lists command and every visible command below it
+func getCompletionNodes(path string, command SubCommand) []completionNode {
+	nodes := []completionNode{{path: path, command: command}}
+	for _, subCommand := range command.SubCommands {
+		if !subCommand.Hidden {
+			nodes = append(nodes, getCompletionNodes(path+" "+subCommand.Name, subCommand)...)
+		}
+	}
+	return nodes
+}
+
+// getSubCommandPaths lists the paths of every command below the slash command, for walking the typed words
+func getSubCommandPaths(nodes []completionNode) []string {
+	paths := make([]string, 0, len(nodes))
+	for _, node := range nodes[1:] {
+		paths = append(paths, node.path)
+	}
+	return paths
+}
+
+// getCompletionWords returns the candidates of a command when no flag is waiting for a value
+func getCompletionWords(command SubCommand) []Completion {
+	var words []Completion
+	for _, subCommand := range command.SubCommands {
+		if !subCommand.Hidden {
+			words = append(words, Completion{Value: subCommand.Name, Description: subCommand.Description})
+		}
+	}
+	if arg, ok := findPosition(command.Arguments, 0); ok && !arg.Hidden {
+		words = append(words, choiceCompletions(arg)...)
+	}
+	return append(words, completeFlags(command.Arguments, nil, "")...)
+}
+
+func choiceCompletions(arg Argument) []Completion {
+	completions := make([]Completion, 0, len(arg.Choices))
+	for _, choice := range arg.Choices {
+		completions = append(completions, Completion{Value: choice.Value, Description: choice.Description})
+	}
+	return completions
+}
+
+// getFlagPattern returns the flags of an argument as a shell case pattern, such as --status|-s
+func getFlagPattern(arg Argument) string {
+	pattern := "--" + arg.Name
+	if arg.ShortName != "" {
+		pattern += "|-" + arg.ShortName
+	}
+	return pattern
+}
+
+// special characters bash and fish expand or split words on
+const (
+	bashSpecial = "\\$`\"'(){}[]<>*?~#&|;! "
+	fishSpecial = "\\$\"'(){}[]<>*?~#&|;^ "
+)
+
+// wordList joins the values of completions into a word list for bash compgen -W or fish complete -a. Both
+// shells expand the list again when completing, so each value is escaped as well as the list being quoted.
+func wordList(completions []Completion, special string) string {
+	values := make([]string, 0, len(completions))
+	for _, completion := range completions {
+		values = append(values, escapeWord(completion.Value, special))
+	}
+	return strings.Join(values, " ")
+}
+
+// escapeWord backslash escapes the special characters in text
+func escapeWord(text string, special string) string {
+	var escaped strings.Builder
+	for _, character := range text {
+		if strings.ContainsRune(special, character) {
+			escaped.WriteRune('\\')
+		}
+		escaped.WriteRune(character)
+	}
+	return escaped.String()
+}
+
+func bashCompletion(program string, function string, nodes []completionNode) string {
+	var script strings.Builder
+	fmt.Fprintf(&script, "# bash completion for %s\n\n", program)
+	fmt.Fprintf(&script, "%s() {\n", function)
+	script.WriteString("    local cur prev path word i\n")
+	script.WriteString("    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	script.WriteString("    prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	fmt.Fprintf(&script, "    path=%s\n", shellQuote(program))
+	script.WriteString("    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	script.WriteString("        word=\"${COMP_WORDS[i]}\"\n")
+	script.WriteString("        case \"${path} ${word}\" in\n")
+	if paths := getSubCommandPaths(nodes); len(paths) > 0 {
+		fmt.Fprintf(&script, "            %s) path=\"${path} ${word}\" ;;\n", quotedPattern(paths))
+	}
+	script.WriteString("        esac\n")
+	script.WriteString("    done\n\n")
+	script.WriteString("    case \"${path}\" in\n")
+	for _, node := range nodes {
+		fmt.Fprintf(&script, "        %s)\n", shellQuote(node.path))
+		if flagCases := bashFlagCases(node.command); flagCases != "" {
+			script.WriteString("            case \"${prev}\" in\n")
+			script.WriteString(flagCases)
+			script.WriteString("            esac\n")
+		}
+		fmt.Fprintf(&script, "            COMPREPLY=($(compgen -W %s -- \"${cur}\"))\n", shellQuote(wordList(getCompletionWords(node.command), bashSpecial)))
+		script.WriteString("            ;;\n")
+	}
+	script.WriteString("    esac\n")
+	script.WriteString("}\n\n")
+	fmt.Fprintf(&script, "complete -F %s %s\n", function, program)
+	return script.String()
+}
+
+func bashFlagCases(command SubCommand) string {
+	var cases strings.Builder
+	for _, arg := range visibleArguments(command.Arguments) {
+		if len(arg.Choices) > 0 {
+			fmt.Fprintf(&cases, "                %s) COMPREPLY=($(compgen -W %s -- \"${cur}\")); return ;;\n", getFlagPattern(arg), shellQuote(wordList(choiceCompletions(arg), bashSpecial)))
+		}
+	}
+	return cases.String()
+}
+
+func zshCompletion(program string, function string, nodes []completionNode) string {
+	var script strings.Builder
+	fmt.Fprintf(&script, "#compdef %s\n\n", program)
+	fmt.Fprintf(&script, "%s() {\n", function)
+	fmt.Fprintf(&script, "    local cmdpath=%s word i\n", shellQuote(program))
+	script.WriteString("    local -a candidates\n")
+	script.WriteString("    for ((i = 2; i < CURRENT; i++)); do\n")
+	script.WriteString("        word=\"${words[i]}\"\n")
+	script.WriteString("        case \"${cmdpath} ${word}\" in\n")
+	if paths := getSubCommandPaths(nodes); len(paths) > 0 {
+		fmt.Fprintf(&script, "            %s) cmdpath=\"${cmdpath} ${word}\" ;;\n", quotedPattern(paths))
+	}
+	script.WriteString("        esac\n")
+	script.WriteString("    done\n\n")
+	script.WriteString("    case \"${cmdpath}\" in\n")
+	for _, node := range nodes {
+		fmt.Fprintf(&script, "        %s)\n", shellQuote(node.path))
+		script.WriteString("            case \"${words[CURRENT-1]}\" in\n")
+		for _, arg := range visibleArguments(node.command.Arguments) {
+			if len(arg.Choices) > 0 {
+				fmt.Fprintf(&script, "                %s) candidates=(%s) ;;\n", getFlagPattern(arg), zshCandidates(choiceCompletions(arg)))
+			}
+		}
+		fmt.Fprintf(&script, "                *) candidates=(%s) ;;\n", zshCandidates(getCompletionWords(node.command)))
+		script.WriteString("            esac\n")
+		script.WriteString("            ;;\n")
+	}
+	script.WriteString("    esac\n")
+	script.WriteString("    _describe 'command' candidates\n")
+	script.WriteString("}\n\n")
+	fmt.Fprintf(&script, "if [ \"$funcstack[1]\" = %s ]; then\n", shellQuote(function))
+	fmt.Fprintf(&script, "    %s \"$@\"\n", function)
+	script.WriteString("else\n")
+	fmt.Fprintf(&script, "    compdef %s %s\n", function, program)
+	script.WriteString("fi\n")
+	return script.String()
+}
+
+// zshCandidates formats completions as value:description items for _describe
+func zshCandidates(completions []Completion) string {
+	candidates := make([]string, 0, len(completions))
+	for _, completion := range completions {
+		candidate := strings.ReplaceAll(completion.Value, ":", `\:`)
+		if completion.Description != "" {
+			candidate += ":" + completion.Description
+		}
+		candidates = append(candidates, shellQuote(candidate))
+	}
+	return strings.Join(candidates, " ")
+}
+
+func fishCompletion(program string, function string, nodes []completionNode) string {
+	var script strings.Builder
+	pathFunction := "_" + function + "_path"
+
+	fmt.Fprintf(&script, "# fish completion for %s\n\n", program)
+	fmt.Fprintf(&script, "function %s\n", pathFunction)
+	fmt.Fprintf(&script, "    set -l path %s\n", fishQuote(program))
+	script.WriteString("    for token in (commandline -opc)[2..-1]\n")
+	script.WriteString("        switch \"$path $token\"\n")
+	if paths := getSubCommandPaths(nodes); len(paths) > 0 {
+		quoted := make([]string, 0, len(paths))
+		for _, path := range paths {
+			quoted = append(quoted, fishQuote(path))
+		}
+		fmt.Fprintf(&script, "            case %s\n", strings.Join(quoted, " "))
+		script.WriteString("                set path \"$path $token\"\n")
+	}
+	script.WriteString("        end\n")
+	script.WriteString("    end\n")
+	script.WriteString("    echo $path\n")
+	script.WriteString("end\n\n")
+	fmt.Fprintf(&script, "complete -c %s -f\n", program)
+
+	for _, node := range nodes {
+		condition := fishQuote(fmt.Sprintf("test (%s) = %s", pathFunction, fishQuote(node.path)))
+		prefix := fmt.Sprintf("complete -c %s -n %s", program, condition)
+
+		for _, subCommand := range node.command.SubCommands {
+			if !subCommand.Hidden {
+				fmt.Fprintf(&script, "%s -a %s%s\n", prefix, fishQuote(escapeWord(subCommand.Name, fishSpecial)), fishDescription(subCommand.Description))
+			}
+		}
+		if arg, ok := findPosition(node.command.Arguments, 0); ok && !arg.Hidden {
+			for _, choice := range arg.Choices {
+				fmt.Fprintf(&script, "%s -a %s%s\n", prefix, fishQuote(escapeWord(choice.Value, fishSpecial)), fishDescription(choice.Description))
+			}
+		}
+		for _, arg := range visibleArguments(node.command.Arguments) {
+			line := prefix + " -l " + fishQuote(arg.Name)
+			if len(arg.ShortName) == 1 {
+				line += " -s " + fishQuote(arg.ShortName)
+			} else if arg.ShortName != "" {
+				line += " -o " + fishQuote(arg.ShortName)
+			}
+			line += fishDescription(arg.Description)
+			if len(arg.Choices) > 0 {
+				line += " -xa " + fishQuote(wordList(choiceCompletions(arg), fishSpecial))
+			} else {
+				line += " -r"
+			}
+			script.WriteString(line + "\n")
+		}
+	}
+	return script.String()
+}
+
+// fishDescription returns the -d option of a fish completion, empty when there is no description
+func fishDescription(description string) string {
+	if description == "" {
+		return ""
+	}
+	return " -d " + fishQuote(description)
+}
+
+// quotedPattern joins paths into a single quoted shell case pattern
+func quotedPattern(paths []string) string {
+	quoted := make([]string, 0, len(paths))
+	for _, path := range paths {
+		quoted = append(quoted, shellQuote(path))
+	}
+	return strings.Join(quoted, "|")
+}
+
+// shellQuote single quotes text for bash and zsh
+func shellQuote(text string) string {
+	return "'" + strings.ReplaceAll(text, "'", `'\''`) + "'"
+}
+
+// fishQuote single quotes text for fish
+func fishQuote(text string) string {
+	text = strings.ReplaceAll(text, `\`, `\\`)
+	return "'" + strings.ReplaceAll(text, "'", `\'`) + "'"
+}
@@ -0,0 +1,128 @@
+package slashparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCompletionScript(t *testing.T) {
+	tests := []struct {
+		name  string
+		shell Shell
+		want  []string
+	}{
+		{
+			name:  "bash",
+			shell: ShellBash,
+			want: []string{
+				`'todo add'|'todo list'|'todo done'|'todo assign'|'todo help') path="${path} ${word}" ;;`,
+				`COMPREPLY=($(compgen -W 'add list done assign help' -- "${cur}"))`,
+				`--status|-s) COMPREPLY=($(compgen -W 'open done all' -- "${cur}")); return ;;`,
+				`COMPREPLY=($(compgen -W 'open done all --status -s' -- "${cur}"))`,
+				"complete -F _todo todo\n",
+			},
+		},
+		{
+			name:  "zsh",
+			shell: ShellZsh,
+			want: []string{
+				"#compdef todo\n",
+				`--status|-s) candidates=('open:Todos that still need doing' 'done:Todos that are finished' 'all') ;;`,
+				`*) candidates=('--id:The ID of the todo' '--user:Who should do it') ;;`,
+				"compdef _todo todo\n",
+			},
+		},
+		{
+			name:  "fish",
+			shell: ShellFish,
+			want: []string{
+				`case 'todo add' 'todo list' 'todo done' 'todo assign' 'todo help'`,
+				`complete -c todo -n 'test (__todo_path) = \'todo\'' -a 'add' -d 'Add a todo'`,
+				`complete -c todo -n 'test (__todo_path) = \'todo list\'' -a 'all'` + "\n",
+				`complete -c todo -n 'test (__todo_path) = \'todo list\'' -l 'status' -s 's' -d 'Which todos to list' -xa 'open done all'`,
+				`complete -c todo -n 'test (__todo_path) = \'todo assign\'' -l 'user' -d 'Who should do it' -r`,
+			},
+		},
+	}
+
+	newSlash, _ := NewSlashCommand(todoDef)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := newSlash.GetCompletionScript(test.shell)
+			assert.NoError(t, err)
+			for _, want := range test.want {
+				assert.Contains(t, got, want)
+			}
+		})
+	}
+}
+
+func TestGetCompletionScriptHidden(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+	got, _ := newSlash.GetCompletionScript(ShellBash)
+
+	assert.Contains(t, got, `'wrangler move thread')`)
+	assert.Contains(t, got, `COMPREPLY=($(compgen -W '--count --trim-length -t --trim' -- "${cur}"))`)
+	assert.NotContains(t, got, "--raw")
+}
+
+func TestGetCompletionScriptQuoting(t *testing.T) {
+	def := []byte(`name: pick
+description: Picks a value
+arguments:
+  - name: value
+    argtype: text
+    description: The value to pick
+    shortName: v
+    position: 0
+    choices:
+      - value: $(touch /tmp/pwned)
+      - value: "` + "`id`" + `"
+      - value: it's
+`)
+	newSlash, err := NewSlashCommand(def)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		shell Shell
+		want  []string
+	}{
+		{
+			shell: ShellBash,
+			want: []string{
+				`--value|-v) COMPREPLY=($(compgen -W '\$\(touch\ /tmp/pwned\) \` + "`" + `id\` + "`" + ` it\'\''s' -- "${cur}")); return ;;`,
+			},
+		},
+		{
+			shell: ShellZsh,
+			want: []string{
+				`--value|-v) candidates=('$(touch /tmp/pwned)' '` + "`id`" + `' 'it'\''s') ;;`,
+			},
+		},
+		{
+			shell: ShellFish,
+			want: []string{
+				`-a '\\$\\(touch\\ /tmp/pwned\\)'`,
+				"-a '`id`'",
+				`-a 'it\\\'s'`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.shell), func(t *testing.T) {
+			got, err := newSlash.GetCompletionScript(test.shell)
+			assert.NoError(t, err)
+			for _, want := range test.want {
+				assert.Contains(t, got, want)
+			}
+		})
+	}
+}
+
+func TestGetCompletionScriptUnknownShell(t *testing.T) {
+	newSlash, _ := NewSlashCommand(todoDef)
+	_, err := newSlash.GetCompletionScript("powershell")
+	assert.EqualError(t, err, "unknown shell powershell")
+}
@@ -0,0 +1,231 @@
+// Package slack serves a slashparse SlashCommand as a Slack slash command webhook
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ericjaystevens/slashparse"
+)
+
+// DefaultMaxAge is how old a request can be before it is rejected as a possible replay
+const DefaultMaxAge = 5 * time.Minute
+
+// maxBodySize is the largest request body read, Slack payloads are far smaller
+const maxBodySize = 1 << 20
+
+var (
+	// ErrInvalidSignature is returned when a request is not signed with the signing secret
+	ErrInvalidSignature = errors.New("invalid slack request signature")
+	// ErrExpiredTimestamp is returned when a request timestamp is outside of the allowed window
+	ErrExpiredTimestamp = errors.New("slack request timestamp is too old")
+	// ErrNoSigningSecret is returned for every request when the handler has no signing secret, since anyone
+	// could sign a request with an empty one
+	ErrNoSigningSecret = errors.New("slack signing secret is not set")
+)
+
+// Handler is an http.Handler that runs slash command POSTs from Slack
+type Handler struct {
+	// Command is the slash command requests are executed with
+	Command *slashparse.SlashCommand
+	// SigningSecret is the signing secret of the Slack app, found on its Basic Information page
+	SigningSecret string
+	// MaxAge is how far a request timestamp can be from now, DefaultMaxAge when zero
+	MaxAge time.Duration
+
+	now func() time.Time
+}
+
+// Message is the JSON Slack expects in reply to a slash command
+type Message struct {
+	ResponseType string       `json:"response_type"`
+	Text         string       `json:"text"`
+	Attachments  []Attachment `json:"attachments,omitempty"`
+}
+
+// Attachment is a Slack message attachment
+type Attachment struct {
+	Title      string   `json:"title,omitempty"`
+	TitleLink  string   `json:"title_link,omitempty"`
+	Text       string   `json:"text,omitempty"`
+	Color      string   `json:"color,omitempty"`
+	ImageURL   string   `json:"image_url,omitempty"`
+	Fields     []Field  `json:"fields,omitempty"`
+	CallbackID string   `json:"callback_id,omitempty"`
+	Actions    []Action `json:"actions,omitempty"`
+}
+
+// Field is a titled value in an attachment
+type Field struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short,omitempty"`
+}
+
+// Action is a button in an attachment
+type Action struct {
+	Name  string `json:"name"`
+	Text  string `json:"text"`
+	Type  string `json:"type"`
+	Style string `json:"style,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// NewHandler creates a Handler that checks requests with signingSecret. A handler without a signing secret
+// rejects every request.
+func NewHandler(command *slashparse.SlashCommand, signingSecret string) *Handler {
+	return &Handler{
+		Command:       command,
+		SigningSecret: signingSecret,
+	}
+}
+
+// ServeHTTP verifies the request, executes the command and writes the response as a Slack message
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "unable to read request", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Verify(r.Header, body); err == ErrNoSigningSecret {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "unable to parse request", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.Command.ExecuteContext(r.Context(), NewRequest(form))
+	if err != nil && response.Text == "" {
+		response = slashparse.Response{Text: err.Error(), Visibility: slashparse.VisibilityEphemeral}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(NewMessage(response))
+}
+
+// Verify checks the X-Slack-Signature header is the v0 signature of the timestamp and body, and the timestamp is recent
+func (h *Handler) Verify(header http.Header, body []byte) error {
+	if h.SigningSecret == "" {
+		return ErrNoSigningSecret
+	}
+
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	maxAge := h.MaxAge
+	if maxAge == 0 {
+		maxAge = DefaultMaxAge
+	}
+	now := time.Now
+	if h.now != nil {
+		now = h.now
+	}
+	if math.Abs(float64(now().Unix()-seconds)) > maxAge.Seconds() {
+		return ErrExpiredTimestamp
+	}
+
+	expected := Sign(h.SigningSecret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature"))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Sign returns the v0 signature Slack sends in the X-Slack-Signature header
+func Sign(signingSecret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewRequest converts the form Slack posts into a slashparse request. The fields without a Request field,
+// such as response_url, are kept in Metadata.
+func NewRequest(form url.Values) slashparse.Request {
+	req := slashparse.Request{
+		UserID:    form.Get("user_id"),
+		ChannelID: form.Get("channel_id"),
+		TeamID:    form.Get("team_id"),
+		Platform:  "slack",
+		Text:      strings.TrimSpace(form.Get("command") + " " + form.Get("text")),
+		Metadata:  make(map[string]string),
+	}
+
+	for _, key := range []string{"response_url", "trigger_id", "user_name", "channel_name", "team_domain", "enterprise_id", "api_app_id"} {
+		if value := form.Get(key); value != "" {
+			req.Metadata[key] = value
+		}
+	}
+	return req
+}
+
+// NewMessage converts a response into a Slack message. Only in channel responses are posted to the channel, the
+// rest are ephemeral. Actions become buttons in an extra attachment; the follow-up URL and metadata have no place
+// in a Slack message and are left out.
+func NewMessage(response slashparse.Response) Message {
+	message := Message{
+		ResponseType: string(slashparse.VisibilityEphemeral),
+		Text:         response.Text,
+	}
+	if response.Visibility == slashparse.VisibilityInChannel {
+		message.ResponseType = string(slashparse.VisibilityInChannel)
+	}
+
+	for _, attachment := range response.Attachments {
+		slackAttachment := Attachment{
+			Title:     attachment.Title,
+			TitleLink: attachment.TitleLink,
+			Text:      attachment.Text,
+			Color:     attachment.Color,
+			ImageURL:  attachment.ImageURL,
+		}
+		for _, field := range attachment.Fields {
+			slackAttachment.Fields = append(slackAttachment.Fields, Field(field))
+		}
+		message.Attachments = append(message.Attachments, slackAttachment)
+	}
+
+	if len(response.Actions) > 0 {
+		actions := Attachment{CallbackID: "slashparse"}
+		for _, action := range response.Actions {
+			actions.Actions = append(actions.Actions, Action{
+				Name:  action.ID,
+				Text:  action.Text,
+				Type:  "button",
+				Style: action.Style,
+				URL:   action.URL,
+				Value: action.Value,
+			})
+		}
+		message.Attachments = append(message.Attachments, actions)
+	}
+	return message
+}
@@ -0,0 +1,174 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ericjaystevens/slashparse"
+	"github.com/stretchr/testify/assert"
+)
+
+const signingSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+var now = time.Unix(1531420618, 0)
+
+func newTestHandler(t *testing.T) *Handler {
+	def, err := ioutil.ReadFile("../testData/todo.yaml")
+	assert.NoError(t, err)
+	command, err := slashparse.NewSlashCommand(def)
+	assert.NoError(t, err)
+
+	command.SetContextHandler("todo add", func(ctx context.Context, req slashparse.Request, values map[string]string) (slashparse.Response, error) {
+		return slashparse.Response{
+			Text:       req.UserID + " added " + values["message"] + " in " + req.ChannelID,
+			Visibility: slashparse.VisibilityInChannel,
+			Actions:    []slashparse.Action{{ID: "undo", Text: "Undo", Value: req.Metadata["response_url"]}},
+		}, nil
+	})
+
+	handler := NewHandler(&command, signingSecret)
+	handler.now = func() time.Time { return now }
+	return handler
+}
+
+func newSignedRequest(form url.Values, timestamp time.Time, secret string) *http.Request {
+	body := form.Encode()
+	seconds := strconv.FormatInt(timestamp.Unix(), 10)
+
+	req := httptest.NewRequest(http.MethodPost, "/slack/todo", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", seconds)
+	req.Header.Set("X-Slack-Signature", Sign(secret, seconds, []byte(body)))
+	return req
+}
+
+func TestServeHTTP(t *testing.T) {
+	form := url.Values{
+		"command":      {"/todo"},
+		"text":         {"add buy milk"},
+		"user_id":      {"U2147483697"},
+		"channel_id":   {"C2147483705"},
+		"team_id":      {"T0001"},
+		"response_url": {"https://hooks.slack.com/commands/1234/5678"},
+	}
+
+	rec := httptest.NewRecorder()
+	newTestHandler(t).ServeHTTP(rec, newSignedRequest(form, now, signingSecret))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var got Message
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, Message{
+		ResponseType: "in_channel",
+		Text:         "U2147483697 added buy milk in C2147483705",
+		Attachments: []Attachment{
+			{
+				CallbackID: "slashparse",
+				Actions: []Action{
+					{Name: "undo", Text: "Undo", Type: "button", Value: "https://hooks.slack.com/commands/1234/5678"},
+				},
+			},
+		},
+	}, got)
+}
+
+func TestServeHTTPErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *http.Request
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "wrong secret",
+			req:      newSignedRequest(url.Values{"command": {"/todo"}, "text": {"list"}}, now, "wrong secret"),
+			wantCode: http.StatusUnauthorized,
+			wantBody: "invalid slack request signature\n",
+		},
+		{
+			name:     "replayed request",
+			req:      newSignedRequest(url.Values{"command": {"/todo"}, "text": {"list"}}, now.Add(-6*time.Minute), signingSecret),
+			wantCode: http.StatusUnauthorized,
+			wantBody: "slack request timestamp is too old\n",
+		},
+		{
+			name:     "get",
+			req:      httptest.NewRequest(http.MethodGet, "/slack/todo", nil),
+			wantCode: http.StatusMethodNotAllowed,
+			wantBody: "method not allowed\n",
+		},
+		{
+			name:     "invalid command",
+			req:      newSignedRequest(url.Values{"command": {"/todo"}, "text": {"done"}}, now, signingSecret),
+			wantCode: http.StatusOK,
+			wantBody: `{"response_type":"ephemeral","text":"required field id is missing, see /todo help for more details"}` + "\n",
+		},
+		{
+			name:     "no handler",
+			req:      newSignedRequest(url.Values{"command": {"/todo"}, "text": {"done 1"}}, now, signingSecret),
+			wantCode: http.StatusOK,
+			wantBody: `{"response_type":"ephemeral","text":"No handler set"}` + "\n",
+		},
+	}
+
+	handler := newTestHandler(t)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, test.req)
+			assert.Equal(t, test.wantCode, rec.Code)
+			assert.Equal(t, test.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestVerifyWithoutSigningSecret(t *testing.T) {
+	handler := newTestHandler(t)
+	handler.SigningSecret = ""
+	req := newSignedRequest(url.Values{"command": {"/todo"}, "text": {"list"}}, now, "")
+	body, _ := ioutil.ReadAll(req.Body)
+
+	assert.Equal(t, ErrNoSigningSecret, handler.Verify(req.Header, body))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newSignedRequest(url.Values{"command": {"/todo"}, "text": {"list"}}, now, ""))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "slack signing secret is not set\n", rec.Body.String())
+}
+
+func TestSign(t *testing.T) {
+	//example from https://api.slack.com/authentication/verifying-requests-from-slack
+	body := "token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J&team_domain=testteamnow&channel_id=G8PSS9T3V&channel_name=foobar&user_id=U2CERLKJA&user_name=roadrunner&command=%2Fwebhook-collect&text=&response_url=https%3A%2F%2Fhooks.slack.com%2Fcommands%2FT1DC2JH3J%2F397700885554%2F96rGlfmibIGlgcZRskXaIFfN&trigger_id=398738663015.47445629121.803a0bc887a14d10d2c447fce8b6703c"
+	got := Sign(signingSecret, "1531420618", []byte(body))
+	assert.Equal(t, "v0=a2114d57b48eac39b9ad189dd8316235a7b4a8d21a10bd27519666489c69b503", got)
+}
+
+func TestNewMessageResponseType(t *testing.T) {
+	tests := []struct {
+		name       string
+		visibility slashparse.Visibility
+		want       string
+	}{
+		{name: "default", visibility: slashparse.VisibilityDefault, want: `{"response_type":"ephemeral","text":"hi"}`},
+		{name: "ephemeral", visibility: slashparse.VisibilityEphemeral, want: `{"response_type":"ephemeral","text":"hi"}`},
+		{name: "in channel", visibility: slashparse.VisibilityInChannel, want: `{"response_type":"in_channel","text":"hi"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := json.Marshal(NewMessage(slashparse.Response{Text: "hi", Visibility: test.visibility}))
+			assert.NoError(t, err)
+			assert.JSONEq(t, test.want, string(got))
+		})
+	}
+}
@@ -2,13 +2,13 @@
 package slashparse
 
 import (
-	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"os"
 	"regexp"
 	"strings"
-	"text/template"
 
 	"github.com/xeipuuv/gojsonschema"
 	"gopkg.in/yaml.v2"
@@ -22,23 +22,91 @@ const (
 
 //Argument defines and argument in a slash command
 type Argument struct {
-	Name        string `yaml:"name" json:"name"`
-	ArgType     string `yaml:"argtype" json:"argtype"`
-	Default     string `yaml:"default" json:"default"`
-	Description string `yaml:"description" json:"description"`
-	ErrorMsg    string `yaml:"errorMsg" json:"errorMsg"`
-	Position    int    `yaml:"position" json:"position"`
-	Required    bool   `yaml:"required" json:"required"`
-	ShortName   string `yaml:"shortName" json:"shortName"`
+	Name           string   `yaml:"name" json:"name"`
+	ArgType        string   `yaml:"argtype" json:"argtype"`
+	Default        string   `yaml:"default" json:"default"`
+	DefaultEnv     string   `yaml:"defaultEnv" json:"defaultEnv"`
+	DefaultContext string   `yaml:"defaultContext" json:"defaultContext"`
+	DefaultFunc    string   `yaml:"defaultFunc" json:"defaultFunc"`
+	Description    string   `yaml:"description" json:"description"`
+	ErrorMsg       string   `yaml:"errorMsg" json:"errorMsg"`
+	Position       int      `yaml:"position" json:"position"`
+	Required       bool     `yaml:"required" json:"required"`
+	ShortName      string   `yaml:"shortName" json:"shortName"`
+	Hidden         bool     `yaml:"hidden" json:"hidden"`
+	Deprecated     string   `yaml:"deprecated" json:"deprecated"`
+	Hint           string   `yaml:"hint" json:"hint"`
+	Choices        []Choice `yaml:"choices" json:"choices,omitempty"`
+	ChoicesURL     string   `yaml:"choicesURL" json:"choicesURL"`
+}
+
+// Choice is one of the values an argument accepts
+type Choice struct {
+	Value       string `yaml:"value" json:"value"`
+	Description string `yaml:"description" json:"description,omitempty"`
+}
+
+// DefaultFunc computes the default value of an argument at parse time. contextValues are the
+// per-request values passed to ParseWithContext, such as the current channel ID.
+type DefaultFunc func(contextValues map[string]string) (string, error)
+
+// Request describes who invoked a slash command, where, and with what text.
+// CommandPath is filled in once the text is parsed, e.g. "wrangler move thread".
+type Request struct {
+	UserID      string
+	ChannelID   string
+	TeamID      string
+	Platform    string
+	Text        string
+	CommandPath string
+	Metadata    map[string]string
+}
+
+// Handler processes a parsed command. ctx is canceled when the caller gives up on the request.
+type Handler func(ctx context.Context, req Request, values map[string]string) (Response, error)
+
+// SimpleHandler adapts a handler that only needs the parsed values and returns text to a Handler
+func SimpleHandler(handler func(map[string]string) (string, error)) Handler {
+	return func(ctx context.Context, req Request, values map[string]string) (Response, error) {
+		msg, err := handler(values)
+		return TextResponse(msg), err
+	}
+}
+
+// ContextValues flattens the request into the values available to defaultContext arguments, the
+// contextValues ExecuteContext passes to ParseWithContext
+func (r Request) ContextValues() map[string]string {
+	values := map[string]string{
+		"userID":    r.UserID,
+		"channelID": r.ChannelID,
+		"teamID":    r.TeamID,
+		"platform":  r.Platform,
+	}
+	for k, v := range r.Metadata {
+		values[k] = v
+	}
+	return values
 }
 
 //SlashCommand defines the structure of a slash command string
 type SlashCommand struct {
 	Name               string       `yaml:"name" json:"name,omitempty"`
 	Description        string       `yaml:"description" json:"description"`
+	LongDescription    string       `yaml:"longDescription" json:"longDescription,omitempty"`
+	Examples           []Example    `yaml:"examples" json:"examples,omitempty"`
+	Notes              []string     `yaml:"notes" json:"notes,omitempty"`
 	Arguments          []Argument   `yaml:"arguments" json:"arguments,omitempty"`
 	SubCommands        []SubCommand `yaml:"subcommands" json:"subcommands,omitempty"`
-	handler            func(map[string]string) (string, error)
+	Roles              []string     `yaml:"roles" json:"roles,omitempty"`
+	Permissions        []string     `yaml:"permissions" json:"permissions,omitempty"`
+	Hidden             bool         `yaml:"hidden" json:"hidden,omitempty"`
+	Deprecated         string       `yaml:"deprecated" json:"deprecated,omitempty"`
+	handler            Handler
+	middleware         []Middleware
+	defaultFuncs       map[string]DefaultFunc
+	authorizer         Authorizer
+	helpFormatters     map[HelpFormat]HelpFormatter
+	completions        map[string]CompletionProvider
 	SubCommandRequired bool `yaml:"subCommandRequired" json:"subCommandRequired"`
 }
 
@@ -46,13 +114,29 @@ type SlashCommand struct {
 type SubCommand struct {
 	Name               string       `yaml:"name" json:"name"`
 	Description        string       `yaml:"description" json:"description"`
-	Arguments          []Argument   `yaml:"arguments" json:"arguments"`
-	SubCommands        []SubCommand `yaml:"subcommands" json:"subcommands"`
+	LongDescription    string       `yaml:"longDescription" json:"longDescription,omitempty"`
+	Examples           []Example    `yaml:"examples" json:"examples,omitempty"`
+	Notes              []string     `yaml:"notes" json:"notes,omitempty"`
+	Arguments          []Argument   `yaml:"arguments" json:"arguments,omitempty"`
+	SubCommands        []SubCommand `yaml:"subcommands" json:"subcommands,omitempty"`
+	Roles              []string     `yaml:"roles" json:"roles,omitempty"`
+	Permissions        []string     `yaml:"permissions" json:"permissions,omitempty"`
+	Hidden             bool         `yaml:"hidden" json:"hidden,omitempty"`
+	Deprecated         string       `yaml:"deprecated" json:"deprecated,omitempty"`
+	Include            string       `yaml:"include" json:"include,omitempty"`
 	commandPaths       []string
-	handler            func(map[string]string) (string, error)
+	handler            Handler
+	middleware         []Middleware
+	builtIn            bool
 	SubCommandRequired bool `yaml:"subCommandRequired" json:"subCommandRequired"`
 }
 
+//Example shows how to run a command and explains what it does
+type Example struct {
+	Command     string `yaml:"command" json:"command"`
+	Description string `yaml:"description" json:"description,omitempty"`
+}
+
 //implimented by SlashCommand and SubCommand
 type command interface {
 	getArgsValues() (map[string]string, error)
@@ -70,6 +154,10 @@ func NewSlashCommand(slashDef []byte) (s SlashCommand, err error) {
 
 //InitSlashCommand initializes the provided slash command
 func InitSlashCommand(s SlashCommand) (SlashCommand, error) {
+	if include, ok := findInclude(s.SubCommands); ok {
+		return s, fmt.Errorf("sub command include %s can only be used when loading from files", include)
+	}
+
 	validationErr := validateSlashDefinition(&s)
 	if validationErr != nil {
 		return s, validationErr
@@ -88,15 +176,43 @@ func InitSlashCommand(s SlashCommand) (SlashCommand, error) {
 	//Add built-in help subcommand
 
 	helpSubcommand := SubCommand{
-		Name:         "help",
-		Description:  "Display help.",
+		Name:        "help",
+		Description: "Display help.",
+		Arguments: []Argument{
+			{
+				Name:        "command",
+				ArgType:     "remaining text",
+				Description: "the command to show help for",
+			},
+		},
 		commandPaths: []string{s.Name + " help"},
-		handler:      func(args map[string]string) (string, error) { return s.GetSlashHelp(), nil },
+		builtIn:      true,
 	}
 	s.SubCommands = append(s.SubCommands, helpSubcommand)
 	return s, nil
 }
 
+// asSubCommand returns the slash command as the root of the sub command tree
+func (s *SlashCommand) asSubCommand() SubCommand {
+	return SubCommand{
+		Name:               s.Name,
+		Description:        s.Description,
+		LongDescription:    s.LongDescription,
+		Examples:           s.Examples,
+		Notes:              s.Notes,
+		Arguments:          s.Arguments,
+		SubCommands:        s.SubCommands,
+		Roles:              s.Roles,
+		Permissions:        s.Permissions,
+		Hidden:             s.Hidden,
+		Deprecated:         s.Deprecated,
+		commandPaths:       []string{s.Name},
+		handler:            s.handler,
+		middleware:         s.middleware,
+		SubCommandRequired: s.SubCommandRequired,
+	}
+}
+
 // getCommandPath gets the full command path that should call a command or sub command
 // hardcoded for now
 func (s *SubCommand) getCommandPath() string {
@@ -107,9 +223,17 @@ func (s *SubCommand) getCommandPath() string {
 
 // SetHandler sets the function that should be called based on the set of slash command and subcommands
 func (s *SlashCommand) SetHandler(commandString string, handler func(map[string]string) (string, error)) error {
+	return s.SetContextHandler(commandString, SimpleHandler(handler))
+}
+
+// SetContextHandler sets a Handler, which also receives the context and request, for a command path.
+// It returns an error if commandString is not a command path of this slash command.
+func (s *SlashCommand) SetContextHandler(commandString string, handler Handler) error {
+	found := false
 
 	if strings.EqualFold(commandString, s.Name) {
 		s.handler = handler
+		found = true
 	}
 
 	for i, subCommand := range s.SubCommands {
@@ -117,62 +241,154 @@ func (s *SlashCommand) SetHandler(commandString string, handler func(map[string]
 
 		if strings.EqualFold(commandString, commandPath) {
 			s.SubCommands[i].handler = handler
+			found = true
 		}
 
 		for subSubCommandPostion, subSubCommand := range subCommand.SubCommands {
 			subSubcommandPath := subSubCommand.getCommandPath()
 			if strings.EqualFold(commandString, subSubcommandPath) {
 				s.SubCommands[i].SubCommands[subSubCommandPostion].handler = handler
+				found = true
 			}
 		}
 	}
+
+	if !found {
+		return fmt.Errorf("unable to set handler, /%s is not a command of /%s", commandString, s.Name)
+	}
 	return nil
 }
 
-func (s *SlashCommand) invokeHandler(commandString string, args map[string]string) (string, error) {
-	if strings.EqualFold(commandString, s.Name) {
-		if s.handler != nil {
-			return s.handler(args)
+// Verify returns an error listing every command path that can be executed but has no handler.
+// Call it after setting handlers, while your application starts or in a test.
+func (s *SlashCommand) Verify() error {
+	var missing []string
+	for _, path := range s.ExecutablePaths() {
+		if !s.hasHandler(path) {
+			missing = append(missing, "/"+path)
 		}
-		return "", errors.New("No handler set")
 	}
 
+	if len(missing) > 0 {
+		return fmt.Errorf("no handler set for %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// MustVerify is like Verify but panics if a handler is missing
+func (s *SlashCommand) MustVerify() {
+	if err := s.Verify(); err != nil {
+		panic(err)
+	}
+}
+
+// hasHandler reports if a handler is set for commandString
+func (s *SlashCommand) hasHandler(commandString string) bool {
+	if strings.EqualFold(commandString, s.Name) {
+		return s.handler != nil
+	}
 	subCommand, err := s.getSubCommand(commandString)
-	if err != nil {
-		return "", err
+	return err == nil && subCommand.handler != nil
+}
+
+// RegisterDefaultFunc registers a function by name so arguments can use it as their defaultFunc
+func (s *SlashCommand) RegisterDefaultFunc(name string, defaultFunc DefaultFunc) error {
+	if name == "" || defaultFunc == nil {
+		return errors.New("a default function needs a name and a function")
 	}
 
-	if subCommand.handler != nil {
-		return subCommand.handler(args)
+	if s.defaultFuncs == nil {
+		s.defaultFuncs = make(map[string]DefaultFunc)
 	}
-	return "", errors.New("No handler set")
+	s.defaultFuncs[name] = defaultFunc
+	return nil
 }
 
-//GetSlashHelp returns a markdown formated help for a slash command
-func (s *SlashCommand) GetSlashHelp() string {
-	funcMap := template.FuncMap{
-		"ToLower": strings.ToLower,
+// getDefaultValue resolves the default of an argument, trying the registered function, the request context,
+// and the environment before falling back to the static default
+func (s *SlashCommand) getDefaultValue(arg Argument, contextValues map[string]string) (string, error) {
+	if arg.DefaultFunc != "" {
+		defaultFunc, ok := s.defaultFuncs[arg.DefaultFunc]
+		if !ok {
+			return "", fmt.Errorf("default function %s for argument %s is not registered", arg.DefaultFunc, arg.Name)
+		}
+		value, err := defaultFunc(contextValues)
+		if err != nil {
+			return "", err
+		}
+		if value != "" {
+			return value, nil
+		}
 	}
 
-	//	helpTemplate, err := template.New("standardHelp.tpl").Funcs(funcMap).ParseFiles("./templates/standardHelp.tpl")
-	helpTemplate, err := template.New("standardHelp.tpl").Funcs(funcMap).Parse(helpTemplateContent)
-	if err != nil {
-		log.Printf("Unable to load help template. %s", err.Error())
-		return ""
+	if arg.DefaultContext != "" {
+		if value := contextValues[arg.DefaultContext]; value != "" {
+			return value, nil
+		}
+	}
+
+	if arg.DefaultEnv != "" {
+		if value := os.Getenv(arg.DefaultEnv); value != "" {
+			return value, nil
+		}
+	}
+
+	return arg.Default, nil
+}
+
+func (s *SlashCommand) invokeHandler(ctx context.Context, req Request, commandString string, args map[string]string) (Response, error) {
+	if err := ctx.Err(); err != nil {
+		return Response{}, err
 	}
 
-	var tpl bytes.Buffer
-	if err := helpTemplate.Execute(&tpl, s); err != nil {
-		log.Printf("Unable to execute help template. %s", err.Error())
+	var handler Handler
+	if strings.EqualFold(commandString, s.Name) {
+		handler = s.handler
+	} else {
+		subCommand, err := s.getSubCommand(commandString)
+		if err != nil {
+			return Response{}, err
+		}
+		handler = subCommand.handler
+		if handler == nil && subCommand.builtIn {
+			handler = s.helpHandler
+		}
+	}
+
+	if handler == nil {
+		handler = func(ctx context.Context, req Request, values map[string]string) (Response, error) {
+			return Response{}, errors.New("No handler set")
+		}
+	}
+
+	req.CommandPath = commandString
+	handler = s.authorize(commandString, handler)
+	return s.applyMiddleware(commandString, handler)(ctx, req, args)
+}
+
+//GetSlashHelp returns a markdown formated help for a slash command. Template errors are logged and
+//an empty string is returned, use GetSlashHelpE to get the error.
+func (s *SlashCommand) GetSlashHelp() string {
+	help, err := s.GetSlashHelpE()
+	if err != nil {
+		log.Printf("Unable to render help. %s", err.Error())
 		return ""
 	}
+	return help
+}
 
-	result := tpl.String()
-	return result
+//GetSlashHelpE is GetSlashHelp returning template errors
+func (s *SlashCommand) GetSlashHelpE() (string, error) {
+	return s.GetHelp(s.Name)
 }
 
 //getValues takes a command and arguments and gets a dictionary of values by argument name
 func (s *SlashCommand) getValues(CommandAndArgs string) (map[string]string, error) {
+	return s.getValuesWithContext(CommandAndArgs, nil)
+}
+
+//getValuesWithContext is getValues with per-request values used to resolve dynamic defaults
+func (s *SlashCommand) getValuesWithContext(CommandAndArgs string, contextValues map[string]string) (map[string]string, error) {
 	m := make(map[string]string)
 
 	//remove command from string
@@ -181,39 +397,61 @@ func (s *SlashCommand) getValues(CommandAndArgs string) (map[string]string, erro
 		return m, err
 	}
 
-	//use regex for case insensitivity
-	re := regexp.MustCompile(`(?i)/` + command)
-	loc := re.FindStringIndex(CommandAndArgs)
-	if len(loc) == 0 {
+	argString, ok := getArgString(command, CommandAndArgs)
+	if !ok {
 		return m, err //command not included in string?
 	}
 
-	if strings.EqualFold(command, s.Name) {
-		return s.getArgsValues(command, CommandAndArgs[loc[1]:], s.Arguments, s.Name)
+	splitArgs, quoted := splitTokens(argString)
+	return s.getCommandValues(command, splitArgs, quoted, contextValues)
+}
+
+//getCommandValues gets the values of the arguments of the command at commandString from the tokens after its path.
+//quoted tells which tokens were quoted, so they are never taken as flags, and may be nil.
+func (s *SlashCommand) getCommandValues(commandString string, splitArgs []string, quoted []bool, contextValues map[string]string) (map[string]string, error) {
+	if strings.EqualFold(commandString, s.Name) {
+		return s.getArgsValues(commandString, splitArgs, quoted, s.Arguments, s.Name, contextValues)
 	}
 
-	subCommand, err := s.getSubCommand(command)
+	subCommand, err := s.getSubCommand(commandString)
 	if err != nil {
-		return m, err
+		return make(map[string]string), err
 	}
 
-	return s.getArgsValues(command, CommandAndArgs[loc[1]:], subCommand.Arguments, s.Name)
+	return s.getArgsValues(commandString, splitArgs, quoted, subCommand.Arguments, s.Name, contextValues)
+}
+
+//isFlag reports if the token at i names an argument, such as --status or -s
+func isFlag(splitArgs []string, quoted []bool, i int) bool {
+	return strings.HasPrefix(splitArgs[i], "-") && !(i < len(quoted) && quoted[i])
+}
 
+//getArgString returns the part of a slash string after the command path
+func getArgString(commandString string, slashString string) (string, bool) {
+	//use regex for case insensitivity
+	re := regexp.MustCompile(`(?i)/` + commandString)
+	loc := re.FindStringIndex(slashString)
+	if len(loc) == 0 {
+		return "", false
+	}
+	return slashString[loc[1]:], true
 }
 
-func (s *SlashCommand) getNamedArgValues(commandString, argString string) (m map[string]string) {
+func (s *SlashCommand) getNamedArgValues(commandString string, splitArgs []string, quoted []bool) (m map[string]string) {
 	m = make(map[string]string)
 
-	splitArgs := GetPositionalArgs(argString)
 	var argumentName string
-	for _, splitArg := range splitArgs {
+	for i, splitArg := range splitArgs {
 		if argumentName != "" {
 			m[argumentName] = splitArg
 			argumentName = ""
 		}
+		if !isFlag(splitArgs, quoted, i) {
+			continue
+		}
 		if strings.HasPrefix(splitArg, "--") {
 			argumentName = splitArg[2:]
-		} else if strings.HasPrefix(splitArg, "-") {
+		} else {
 			argument, _ := s.getArgumentFromShortName(commandString, splitArg[1:])
 			argumentName = argument.Name
 		}
@@ -244,20 +482,16 @@ func (s *SlashCommand) getArgumentFromShortName(commandString string, shortName
 	return argument, fmt.Errorf("Unknown paramater '%s', see /%s help for more details", shortName, commandString)
 }
 
-func (s *SlashCommand) getArgsValues(commandString string, argString string, commandArgs []Argument, slashCommandName string) (m map[string]string, err error) {
+func (s *SlashCommand) getArgsValues(commandString string, splitArgs []string, quoted []bool, commandArgs []Argument, slashCommandName string, contextValues map[string]string) (m map[string]string, err error) {
 
 	m = make(map[string]string)
 	missingArgs := make([]string, 0, 8)
-	splitArgs := GetPositionalArgs(argString)
+	var unsetArgs []Argument
 
 	for _, commandArg := range commandArgs {
-		if commandArg.Default != "" {
-			m[commandArg.Name] = commandArg.Default
-		}
-
 		position := commandArg.Position
 		if len(splitArgs) > position {
-			if strings.HasPrefix(splitArgs[position], "-") {
+			if isFlag(splitArgs, quoted, position) {
 				break
 			}
 			switch commandArg.ArgType {
@@ -266,23 +500,34 @@ func (s *SlashCommand) getArgsValues(commandString string, argString string, com
 			case "remaining text":
 				m[commandArg.Name] = strings.Join(splitArgs[position:], " ")
 			}
-		} else {
-			if commandArg.Required {
-				missingArgs = append(missingArgs, commandArg.Name)
-			}
+		} else if commandArg.Required {
+			unsetArgs = append(unsetArgs, commandArg)
 		}
 	}
 
-	namedMap := s.getNamedArgValues(commandString, argString)
+	namedMap := s.getNamedArgValues(commandString, splitArgs, quoted)
 
 	for k, v := range namedMap {
 		m[k] = v
-		for i, missingArg := range missingArgs {
-			if missingArg == v {
-				missingArgs[i] = missingArgs[len(missingArgs)-1]
-				missingArgs[len(missingArgs)-1] = ""
-				missingArg = missingArg[:len(missingArg)-1]
-			}
+	}
+
+	//resolve defaults last, so a defaultFunc only runs for arguments that weren't given
+	for _, commandArg := range commandArgs {
+		if _, ok := m[commandArg.Name]; ok {
+			continue
+		}
+		defaultValue, err := s.getDefaultValue(commandArg, contextValues)
+		if err != nil {
+			return m, err
+		}
+		if defaultValue != "" {
+			m[commandArg.Name] = defaultValue
+		}
+	}
+
+	for _, commandArg := range unsetArgs {
+		if m[commandArg.Name] == "" {
+			missingArgs = append(missingArgs, commandArg.Name)
 		}
 	}
 
@@ -352,12 +597,17 @@ func (s *SlashCommand) getCommandString(args string) (commandString string, err
 
 //Parse parse the command string
 func (s *SlashCommand) Parse(slashString string) (string, map[string]string, error) {
+	return s.ParseWithContext(slashString, nil)
+}
+
+//ParseWithContext parses the command string, resolving defaultContext arguments from contextValues
+func (s *SlashCommand) ParseWithContext(slashString string, contextValues map[string]string) (string, map[string]string, error) {
 	commandString, err := s.getCommandString(slashString)
 	if err != nil {
 		return "", nil, err
 	}
 
-	values, err := s.getValues(slashString)
+	values, err := s.getValuesWithContext(slashString, contextValues)
 	if err != nil {
 		return "", nil, err
 	}
@@ -367,24 +617,60 @@ func (s *SlashCommand) Parse(slashString string) (string, map[string]string, err
 
 //Execute parses and runs the configured handler to process your command.
 func (s *SlashCommand) Execute(slashString string) (string, error) {
-	commandString, values, err := s.Parse(slashString)
+	response, err := s.ExecuteContext(context.Background(), Request{Text: slashString})
+	return response.Text, err
+}
+
+//ExecuteContext parses req.Text and runs the configured handler with the context and request.
+//Parse errors are returned as an ephemeral response as well as an error.
+func (s *SlashCommand) ExecuteContext(ctx context.Context, req Request) (Response, error) {
+	if helpPath, ok := s.getHelpPath(req.Text); ok {
+		command := strings.TrimSpace(helpPath[len(s.Name):])
+		return s.invokeHandler(ctx, req, s.Name+" help", map[string]string{"command": command})
+	}
+
+	commandString, values, err := s.ParseWithContext(req.Text, req.ContextValues())
 	if err != nil {
-		return err.Error(), err
+		return Response{Text: err.Error(), Visibility: VisibilityEphemeral}, err
 	}
 
-	msg, err := s.invokeHandler(commandString, values)
-	return msg, err
+	response, err := s.invokeHandler(ctx, req, commandString, values)
+	if err != nil {
+		return response, err
+	}
+	argString, _ := getArgString(commandString, req.Text)
+	splitArgs, quoted := splitTokens(argString)
+	return appendWarnings(response, s.getDeprecationWarnings(commandString, splitArgs, quoted)), nil
 }
 
 //GetPositionalArgs takes a string of arguments and splits it up by spaces and double quotes
 func GetPositionalArgs(argString string) []string {
+	args, _ := splitTokens(argString)
+	return args
+}
+
+// splitTokens splits argString like GetPositionalArgs, also reporting which tokens were quoted
+func splitTokens(argString string) ([]string, []bool) {
 	var isQuoteText bool
 	var previousCharacter rune
 	args := make([]string, 0, 20)
+	quoted := make([]bool, 0, 20)
 	currentPosition := 0
 	var currentArg string
+	var escaped bool
 
 	for _, character := range argString {
+		if escaped {
+			//a character after a backslash in quoted text, only quotes and backslashes are escaped
+			if character != doubleQuote && character != backspace {
+				currentArg += string(backspace)
+			}
+			currentArg += string(character)
+			escaped = false
+			previousCharacter = character
+			continue
+		}
+
 		switch character {
 		case space:
 			if len(currentArg) > 0 {
@@ -394,16 +680,24 @@ func GetPositionalArgs(argString string) []string {
 					// ignore duplicate spaces between
 					if previousCharacter != space {
 						args = append(args, currentArg)
+						quoted = append(quoted, false)
 						currentPosition++
 						currentArg = ""
 					}
 				}
 			}
+		case backspace:
+			if isQuoteText {
+				escaped = true
+			} else {
+				currentArg += string(character)
+			}
 		case doubleQuote:
 			if isQuoteText {
 				//this is and end quote
 				isQuoteText = false
 				args = append(args, currentArg)
+				quoted = append(quoted, true)
 				currentPosition++
 				currentArg = ""
 			} else {
@@ -420,10 +714,14 @@ func GetPositionalArgs(argString string) []string {
 		previousCharacter = character
 	}
 
+	if escaped {
+		currentArg += string(backspace)
+	}
 	if len(currentArg) > 0 {
 		args = append(args, currentArg)
+		quoted = append(quoted, false)
 	}
-	return args
+	return args, quoted
 }
 
 func validateSlashDefinition(slashCommandDef *SlashCommand) (err error) {
@@ -444,6 +742,56 @@ func validateSlashDefinition(slashCommandDef *SlashCommand) (err error) {
 	return errors.New("Slash Command Definition is not valid")
 }
 
+// CommandPaths returns the path of the slash command and of every sub command below it, such as
+// "wrangler move thread", leaving out the built-in help
+func (s *SlashCommand) CommandPaths() []string {
+	paths := []string{s.Name}
+	for _, subCommand := range s.SubCommands {
+		if subCommand.builtIn {
+			continue
+		}
+		paths = append(paths, subCommand.getCommandPath())
+		for _, subSubCommand := range subCommand.SubCommands {
+			paths = append(paths, subSubCommand.getCommandPath())
+		}
+	}
+	return paths
+}
+
+// ExecutablePaths returns the command paths that run a handler: commands without sub commands, and commands
+// with sub commands that don't require one. The built-in help is left out.
+func (s *SlashCommand) ExecutablePaths() []string {
+	var paths []string
+	if len(s.SubCommands) <= 1 || !s.SubCommandRequired {
+		paths = append(paths, s.Name)
+	}
+	for _, subCommand := range s.SubCommands {
+		if subCommand.builtIn {
+			continue
+		}
+		if len(subCommand.SubCommands) == 0 || !subCommand.SubCommandRequired {
+			paths = append(paths, subCommand.getCommandPath())
+		}
+		for _, subSubCommand := range subCommand.SubCommands {
+			paths = append(paths, subSubCommand.getCommandPath())
+		}
+	}
+	return paths
+}
+
+// getSubCommandChain returns each sub command along a command path, starting below the slash command
+func (s *SlashCommand) getSubCommandChain(commandString string) []SubCommand {
+	var chain []SubCommand
+	words := strings.Fields(commandString)
+	for i := 2; i <= len(words); i++ {
+		subCommand, err := s.getSubCommand(strings.Join(words[:i], " "))
+		if err == nil {
+			chain = append(chain, subCommand)
+		}
+	}
+	return chain
+}
+
 func (s *SlashCommand) getSubCommand(commandString string) (SubCommand, error) {
 
 	for _, subCommand := range s.SubCommands {
@@ -1,8 +1,10 @@
 package slashparse
 
 import (
+	"context"
 	"errors"
 	"io/ioutil"
+	"os"
 	"strings"
 	"testing"
 
@@ -249,6 +251,13 @@ func TestGetPositionalArgs(t *testing.T) {
 	got := GetPositionalArgs("foo \"man chu\"  \\choo wow")
 	want := []string{"foo", "man chu", "\\choo", "wow"}
 	assert.Equal(t, want, got)
+	got = GetPositionalArgs(`say "he said \"hi\"" \"there`)
+	want = []string{"say", `he said "hi"`, `"there`}
+	assert.Equal(t, want, got)
+
+	got = GetPositionalArgs(`"C:\\new folder\\" "a\b" c\`)
+	want = []string{`C:\new folder\`, `a\b`, `c\`}
+	assert.Equal(t, want, got)
 }
 
 type getValuesTests struct {
@@ -437,6 +446,46 @@ func TestValidateSlashDefinition(t *testing.T) {
 	}
 }
 
+func TestValidateExamples(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     string
+		wantErr bool
+	}{
+		{
+			name:    "example with a command",
+			def:     "name: print\ndescription: Prints things\nexamples:\n  - command: /print hello\n",
+			wantErr: false,
+		},
+		{
+			name:    "example without a command",
+			def:     "name: print\ndescription: Prints things\nexamples:\n  - description: prints hello\n",
+			wantErr: true,
+		},
+		{
+			name:    "sub command example without a command",
+			def:     "name: print\ndescription: Prints things\nsubcommands:\n  - name: reverse\n    description: Prints backwards\n    examples:\n      - description: prints olleh\n",
+			wantErr: true,
+		},
+		{
+			name:    "sub sub command example without a command",
+			def:     "name: print\ndescription: Prints things\nsubcommands:\n  - name: quote\n    description: Prints quotes\n    subcommands:\n      - name: random\n        description: Prints a random quote\n        examples:\n          - command: \"\"\n",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewSlashCommand([]byte(test.def))
+			if test.wantErr {
+				assert.EqualError(t, err, "Slash Command Definition is not valid")
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
 func TestSetHandler(t *testing.T) {
 	newSlash, _ := NewSlashCommand(SimpleDef)
 	commandString, _, _ := newSlash.Parse("/print reverse pickle")
@@ -447,6 +496,29 @@ func TestSetHandler(t *testing.T) {
 	got := newSlash.SetHandler(commandString, myHandler)
 
 	assert.Nil(t, got)
+
+	err := newSlash.SetHandler("print backwards", myHandler)
+
+	assert.EqualError(t, err, "unable to set handler, /print backwards is not a command of /Print")
+}
+
+func TestVerify(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+	handler := func(args map[string]string) (string, error) { return "", nil }
+	for _, path := range []string{"wrangler info", "wrangler move thread", "wrangler copy thread", "wrangler attach message"} {
+		_ = newSlash.SetHandler(path, handler)
+	}
+
+	err := newSlash.Verify()
+
+	assert.EqualError(t, err, "no handler set for /wrangler list channels, /wrangler list messages")
+	assert.Panics(t, newSlash.MustVerify)
+
+	_ = newSlash.SetHandler("wrangler list channels", handler)
+	_ = newSlash.SetHandler("wrangler list messages", handler)
+
+	assert.Nil(t, newSlash.Verify())
+	assert.NotPanics(t, newSlash.MustVerify)
 }
 
 type invokeHandlerTests struct {
@@ -486,16 +558,16 @@ func TestInvokeHandler(t *testing.T) {
 			commandString, values, _ := newSlash.Parse(test.commandString)
 
 			_ = newSlash.SetHandler(commandString, test.handler)
-			got, _ := newSlash.invokeHandler(commandString, values)
+			got, _ := newSlash.invokeHandler(context.Background(), Request{}, commandString, values)
 
-			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.want, got.Text)
 		})
 	}
 
 	t.Run("invoke without setting handler", func(t *testing.T) {
 		newSlash, _ := NewSlashCommand(SimpleDef)
 		commandString, values, _ := newSlash.Parse("/print reverse pick")
-		_, err := newSlash.invokeHandler(commandString, values)
+		_, err := newSlash.invokeHandler(context.Background(), Request{}, commandString, values)
 
 		assert.EqualError(t, err, "No handler set")
 	})
@@ -595,6 +667,28 @@ func TestGetSubCommand(t *testing.T) {
 
 }
 
+func TestCommandPaths(t *testing.T) {
+	newSlash, _ := NewSlashCommand(SimpleDef)
+	want := []string{"Print", "Print reverse", "Print quote", "Print quote random", "Print quote author"}
+	assert.Equal(t, want, newSlash.CommandPaths())
+}
+
+func TestExecutablePaths(t *testing.T) {
+	newSlash, _ := NewSlashCommand(SimpleDef)
+	assert.Equal(t, []string{"Print", "Print reverse", "Print quote", "Print quote random", "Print quote author"}, newSlash.ExecutablePaths())
+
+	wrangler, _ := NewSlashCommand(wranglerDef)
+	want := []string{
+		"wrangler info",
+		"wrangler move thread",
+		"wrangler copy thread",
+		"wrangler attach message",
+		"wrangler list channels",
+		"wrangler list messages",
+	}
+	assert.Equal(t, want, wrangler.ExecutablePaths())
+}
+
 func TestGetSlashHelp(t *testing.T) {
 	newSlash, _ := NewSlashCommand(SimpleDef)
 	commandString := "print help"
@@ -642,3 +736,150 @@ func TestArgumentTypes(t *testing.T) {
 
 	}
 }
+
+var defaultsDef, _ = ioutil.ReadFile("./testData/defaults.yaml")
+
+type dynamicDefaultsTests struct {
+	name          string
+	commandString string
+	contextValues map[string]string
+	env           string
+	want          map[string]string
+}
+
+func TestDynamicDefaults(t *testing.T) {
+
+	tests := []dynamicDefaultsTests{
+		{
+			name:          "static and function defaults",
+			commandString: "/remind lunch",
+			want:          map[string]string{"message": "lunch", "timezone": "UTC", "when": "tomorrow at 9"},
+		},
+		{
+			name:          "default from context",
+			commandString: "/remind lunch",
+			contextValues: map[string]string{"channelID": "town-square"},
+			want:          map[string]string{"message": "lunch", "channel": "town-square", "timezone": "UTC", "when": "tomorrow at 9"},
+		},
+		{
+			name:          "default from environment",
+			commandString: "/remind lunch",
+			env:           "America/Chicago",
+			want:          map[string]string{"message": "lunch", "timezone": "America/Chicago", "when": "tomorrow at 9"},
+		},
+		{
+			name:          "explicit values win over defaults",
+			commandString: "/remind lunch off-topic -z Europe/Paris --when today",
+			contextValues: map[string]string{"channelID": "town-square"},
+			env:           "America/Chicago",
+			want:          map[string]string{"message": "lunch", "channel": "off-topic", "timezone": "Europe/Paris", "when": "today"},
+		},
+		{
+			name:          "defaults after a named argument",
+			commandString: "/remind -w today",
+			contextValues: map[string]string{"channelID": "town-square"},
+			want:          map[string]string{"channel": "town-square", "timezone": "UTC", "when": "today"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			os.Setenv("SLASHPARSE_TEST_TZ", test.env)
+			defer os.Unsetenv("SLASHPARSE_TEST_TZ")
+
+			newSlash, _ := NewSlashCommand(defaultsDef)
+			_ = newSlash.RegisterDefaultFunc("tomorrow", func(contextValues map[string]string) (string, error) {
+				return "tomorrow at 9", nil
+			})
+
+			_, got, err := newSlash.ParseWithContext(test.commandString, test.contextValues)
+			assert.Nil(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("unregistered default function", func(t *testing.T) {
+		newSlash, _ := NewSlashCommand(defaultsDef)
+		_, _, err := newSlash.Parse("/remind lunch")
+
+		assert.EqualError(t, err, "default function tomorrow for argument when is not registered")
+	})
+
+	t.Run("default function is not run when the value is given", func(t *testing.T) {
+		newSlash, _ := NewSlashCommand(defaultsDef)
+		for _, commandString := range []string{"/remind lunch off-topic UTC today", "/remind lunch --when today"} {
+			_, got, err := newSlash.Parse(commandString)
+
+			assert.Nil(t, err)
+			assert.Equal(t, "today", got["when"])
+		}
+
+		calls := 0
+		_ = newSlash.RegisterDefaultFunc("tomorrow", func(contextValues map[string]string) (string, error) {
+			calls++
+			return "tomorrow at 9", nil
+		})
+		_, _, err := newSlash.Parse("/remind lunch -w today")
+		assert.Nil(t, err)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("wrangler channelID defaults to current channel", func(t *testing.T) {
+		newSlash, _ := NewSlashCommand(wranglerDef)
+		_, got, _ := newSlash.ParseWithContext("/wrangler move thread abc123", map[string]string{"channelID": "current"})
+
+		assert.Equal(t, map[string]string{"messageID": "abc123", "channelID": "current"}, got)
+	})
+}
+
+func TestExecuteContext(t *testing.T) {
+	newSlash, _ := NewSlashCommand(wranglerDef)
+	_ = newSlash.SetContextHandler("wrangler move thread", func(ctx context.Context, req Request, values map[string]string) (Response, error) {
+		return Response{
+			Text:       req.UserID + " moved " + values["messageID"] + " to " + values["channelID"] + " on " + req.Platform + " for " + req.Metadata["botName"],
+			Visibility: VisibilityInChannel,
+			Actions:    []Action{{ID: "undo", Text: "Undo", Value: values["messageID"]}},
+		}, nil
+	})
+	_ = newSlash.SetHandler("wrangler info", func(values map[string]string) (string, error) {
+		return "wrangler info", nil
+	})
+
+	t.Run("request is passed to the handler", func(t *testing.T) {
+		req := Request{
+			UserID:    "ann",
+			ChannelID: "town-square",
+			Platform:  "mattermost",
+			Text:      "/wrangler move thread abc123",
+			Metadata:  map[string]string{"botName": "wranglerbot"},
+		}
+		got, err := newSlash.ExecuteContext(context.Background(), req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "ann moved abc123 to town-square on mattermost for wranglerbot", got.Text)
+		assert.Equal(t, VisibilityInChannel, got.Visibility)
+		assert.Equal(t, []Action{{ID: "undo", Text: "Undo", Value: "abc123"}}, got.Actions)
+	})
+
+	t.Run("simple handlers still work", func(t *testing.T) {
+		got, err := newSlash.ExecuteContext(context.Background(), Request{Text: "/wrangler info"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, TextResponse("wrangler info"), got)
+	})
+
+	t.Run("parse errors are ephemeral", func(t *testing.T) {
+		got, err := newSlash.ExecuteContext(context.Background(), Request{Text: "/wrangler move"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, Response{Text: err.Error(), Visibility: VisibilityEphemeral}, got)
+	})
+
+	t.Run("canceled context is not executed", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := newSlash.ExecuteContext(ctx, Request{Text: "/wrangler info"})
+
+		assert.Equal(t, context.Canceled, err)
+	})
+}
@@ -0,0 +1,294 @@
+// Package telegram runs a slashparse SlashCommand as a Telegram bot command
+package telegram
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ericjaystevens/slashparse"
+)
+
+// Update is the part of a Telegram update the adapter reads
+type Update struct {
+	UpdateID int      `json:"update_id"`
+	Message  *Message `json:"message,omitempty"`
+}
+
+// Message is a Telegram message
+type Message struct {
+	MessageID int    `json:"message_id"`
+	From      *User  `json:"from,omitempty"`
+	Chat      Chat   `json:"chat"`
+	Text      string `json:"text,omitempty"`
+}
+
+// User is the Telegram user that sent a message
+type User struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username,omitempty"`
+}
+
+// Chat is the chat a message was sent in
+type Chat struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+// SendMessage is the sendMessage method of the Bot API, returned in the body of a webhook reply
+type SendMessage struct {
+	Method           string                `json:"method"`
+	ChatID           int64                 `json:"chat_id"`
+	Text             string                `json:"text"`
+	ReplyToMessageID int                   `json:"reply_to_message_id,omitempty"`
+	ReplyMarkup      *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+}
+
+// InlineKeyboardMarkup is a set of buttons shown under a message
+type InlineKeyboardMarkup struct {
+	InlineKeyboard [][]InlineKeyboardButton `json:"inline_keyboard"`
+}
+
+// InlineKeyboardButton opens URL or sends CallbackData back to the bot
+type InlineKeyboardButton struct {
+	Text         string `json:"text"`
+	URL          string `json:"url,omitempty"`
+	CallbackData string `json:"callback_data,omitempty"`
+}
+
+// BotCommand is a command in the list set with setMyCommands
+type BotCommand struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+}
+
+// SetMyCommands is the body of the setMyCommands method of the Bot API
+type SetMyCommands struct {
+	Commands []BotCommand `json:"commands"`
+}
+
+// Telegram limits of bot commands
+const (
+	commandMaxLength     = 32
+	descriptionMaxLength = 256
+	callbackDataMaxBytes = 64
+)
+
+var commandPattern = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+// Handler is an http.Handler for the webhook of a Telegram bot. It answers commands by returning
+// a sendMessage call in the body of the reply, and ignores other updates.
+type Handler struct {
+	// Command is the slash command messages are executed with
+	Command *slashparse.SlashCommand
+	// BotName is the username of the bot, used to tell commands for this bot from commands for others in groups
+	BotName string
+	// SecretToken is the secret_token passed to setWebhook, requests without it are rejected. Not checked when empty.
+	SecretToken string
+}
+
+// NewHandler creates a Handler for the bot with the username botName
+func NewHandler(command *slashparse.SlashCommand, botName string) *Handler {
+	return &Handler{
+		Command: command,
+		BotName: botName,
+	}
+}
+
+// ServeHTTP executes the command in the update and replies with the response
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.SecretToken != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Telegram-Bot-Api-Secret-Token")), []byte(h.SecretToken)) != 1 {
+		http.Error(w, "invalid secret token", http.StatusUnauthorized)
+		return
+	}
+
+	var update Update
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&update); err != nil {
+		http.Error(w, "unable to parse update", http.StatusBadRequest)
+		return
+	}
+
+	reply, ok := h.HandleUpdate(r.Context(), update)
+	if !ok {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reply)
+}
+
+// HandleUpdate executes the command in the update, ok is false when the update is not a command for this slash command
+func (h *Handler) HandleUpdate(ctx context.Context, update Update) (reply SendMessage, ok bool) {
+	if update.Message == nil {
+		return SendMessage{}, false
+	}
+
+	req, ok := NewRequest(*update.Message, h.BotName)
+	if !ok || !isCommand(req.Text, h.Command.Name) {
+		return SendMessage{}, false
+	}
+
+	response, err := h.Command.ExecuteContext(ctx, req)
+	if err != nil && response.Text == "" {
+		response = slashparse.Response{Text: err.Error(), Visibility: slashparse.VisibilityEphemeral}
+	}
+	return NewSendMessage(*update.Message, response), true
+}
+
+// StripBotName removes the @BotName suffix from the command in text, as in /wrangler@MyBot move thread.
+// ok is false when the command is addressed to another bot.
+func StripBotName(text string, botName string) (string, bool) {
+	if !strings.HasPrefix(text, "/") {
+		return text, true
+	}
+
+	end := strings.IndexAny(text, " \n")
+	if end == -1 {
+		end = len(text)
+	}
+	command := text[:end]
+
+	at := strings.Index(command, "@")
+	if at == -1 {
+		return text, true
+	}
+	if !strings.EqualFold(command[at+1:], strings.TrimPrefix(botName, "@")) {
+		return text, false
+	}
+	return command[:at] + text[end:], true
+}
+
+// NewRequest converts a message into a slashparse request. The user ID is the sender and the channel ID the chat;
+// both are also in Metadata as userID and chatID, with chatType, messageID and userName. ok is false when
+// the command is addressed to another bot.
+func NewRequest(message Message, botName string) (slashparse.Request, bool) {
+	text, ok := StripBotName(message.Text, botName)
+	if !ok {
+		return slashparse.Request{}, false
+	}
+
+	chatID := strconv.FormatInt(message.Chat.ID, 10)
+	req := slashparse.Request{
+		ChannelID: chatID,
+		Platform:  "telegram",
+		Text:      text,
+		Metadata: map[string]string{
+			"chatID":    chatID,
+			"chatType":  message.Chat.Type,
+			"messageID": strconv.Itoa(message.MessageID),
+		},
+	}
+
+	if message.From != nil {
+		req.UserID = strconv.FormatInt(message.From.ID, 10)
+		req.Metadata["userID"] = req.UserID
+		req.Metadata["userName"] = message.From.Username
+	}
+	return req, true
+}
+
+// NewSendMessage converts a response into a reply to message. Telegram has no ephemeral messages, so
+// ephemeral responses are sent as replies to the command. Attachments are added to the text and actions
+// become inline keyboard buttons; the follow-up URL and metadata are left out.
+func NewSendMessage(message Message, response slashparse.Response) SendMessage {
+	reply := SendMessage{
+		Method: "sendMessage",
+		ChatID: message.Chat.ID,
+		Text:   response.Text,
+	}
+	if response.Visibility == slashparse.VisibilityEphemeral {
+		reply.ReplyToMessageID = message.MessageID
+	}
+
+	for _, attachment := range response.Attachments {
+		reply.Text += "\n\n" + attachmentText(attachment)
+	}
+
+	if len(response.Actions) > 0 {
+		var row []InlineKeyboardButton
+		for _, action := range response.Actions {
+			button := InlineKeyboardButton{Text: action.Text, URL: action.URL}
+			if action.URL == "" {
+				button.CallbackData = truncateBytes(action.ID+":"+action.Value, callbackDataMaxBytes)
+			}
+			row = append(row, button)
+		}
+		reply.ReplyMarkup = &InlineKeyboardMarkup{InlineKeyboard: [][]InlineKeyboardButton{row}}
+	}
+	return reply
+}
+
+// GetBotCommands exports the slash commands in the setMyCommands format, with the problems Telegram
+// would reject, such as upper case names or long descriptions
+func GetBotCommands(commands ...*slashparse.SlashCommand) (SetMyCommands, []slashparse.ExportIssue) {
+	var issues []slashparse.ExportIssue
+	botCommands := SetMyCommands{Commands: []BotCommand{}}
+
+	for _, command := range commands {
+		if command.Hidden {
+			continue
+		}
+		botCommand := BotCommand{
+			Command:     strings.ToLower(command.Name),
+			Description: command.Description,
+		}
+		if !commandPattern.MatchString(botCommand.Command) {
+			issues = append(issues, slashparse.ExportIssue{Path: command.Name, Message: fmt.Sprintf("Telegram commands must be 1 to %d lower case letters, numbers or _", commandMaxLength)})
+		}
+		if botCommand.Description == "" {
+			issues = append(issues, slashparse.ExportIssue{Path: command.Name, Message: "Telegram requires a description"})
+		}
+		if utf8.RuneCountInString(botCommand.Description) > descriptionMaxLength {
+			botCommand.Description = string([]rune(botCommand.Description)[:descriptionMaxLength])
+			issues = append(issues, slashparse.ExportIssue{Path: command.Name, Message: fmt.Sprintf("description was cut to %d characters", descriptionMaxLength)})
+		}
+		botCommands.Commands = append(botCommands.Commands, botCommand)
+	}
+	return botCommands, issues
+}
+
+// isCommand returns true when text runs the slash command name
+func isCommand(text string, name string) bool {
+	fields := strings.Fields(text)
+	return len(fields) > 0 && strings.EqualFold(fields[0], "/"+name)
+}
+
+func attachmentText(attachment slashparse.Attachment) string {
+	var lines []string
+	if attachment.Title != "" {
+		lines = append(lines, attachment.Title)
+	}
+	if attachment.Text != "" {
+		lines = append(lines, attachment.Text)
+	}
+	for _, field := range attachment.Fields {
+		lines = append(lines, field.Title+": "+field.Value)
+	}
+	if attachment.TitleLink != "" {
+		lines = append(lines, attachment.TitleLink)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// truncateBytes cuts text to at most max bytes without splitting a character
+func truncateBytes(text string, max int) string {
+	if len(text) <= max {
+		return text
+	}
+	for max > 0 && !utf8.RuneStart(text[max]) {
+		max--
+	}
+	return text[:max]
+}
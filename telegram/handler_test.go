@@ -0,0 +1,150 @@
+package telegram
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ericjaystevens/slashparse"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCommand(t *testing.T) *slashparse.SlashCommand {
+	def, err := ioutil.ReadFile("../testData/todo.yaml")
+	assert.NoError(t, err)
+	command, err := slashparse.NewSlashCommand(def)
+	assert.NoError(t, err)
+
+	command.SetContextHandler("todo add", func(ctx context.Context, req slashparse.Request, values map[string]string) (slashparse.Response, error) {
+		return slashparse.Response{
+			Text:    req.Metadata["userName"] + " added " + values["message"] + " in " + req.ChannelID,
+			Actions: []slashparse.Action{{ID: "done", Text: "Done", Value: "12"}},
+		}, nil
+	})
+	return &command
+}
+
+func TestStripBotName(t *testing.T) {
+	tests := []struct {
+		text   string
+		want   string
+		wantOk bool
+	}{
+		{"/wrangler@MyBot move thread 1234", "/wrangler move thread 1234", true},
+		{"/wrangler@mybot", "/wrangler", true},
+		{"/wrangler move thread", "/wrangler move thread", true},
+		{"/wrangler@OtherBot move thread", "", false},
+		{"hello @MyBot", "hello @MyBot", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			got, ok := StripBotName(test.text, "MyBot")
+			assert.Equal(t, test.wantOk, ok)
+			if ok {
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+func TestNewRequest(t *testing.T) {
+	got, ok := NewRequest(Message{
+		MessageID: 42,
+		From:      &User{ID: 111222333, Username: "mason"},
+		Chat:      Chat{ID: -1001234567890, Type: "supergroup"},
+		Text:      "/todo@TodoBot add buy milk",
+	}, "TodoBot")
+
+	assert.True(t, ok)
+	assert.Equal(t, slashparse.Request{
+		UserID:    "111222333",
+		ChannelID: "-1001234567890",
+		Platform:  "telegram",
+		Text:      "/todo add buy milk",
+		Metadata: map[string]string{
+			"chatID":    "-1001234567890",
+			"chatType":  "supergroup",
+			"messageID": "42",
+			"userID":    "111222333",
+			"userName":  "mason",
+		},
+	}, got)
+}
+
+func TestServeHTTP(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		token    string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "command",
+			body:     `{"update_id":10000,"message":{"message_id":42,"from":{"id":1111111,"username":"mason"},"chat":{"id":1111111,"type":"private"},"date":1441645532,"text":"/todo@TodoBot add buy milk"}}`,
+			token:    "secret",
+			wantCode: http.StatusOK,
+			wantBody: `{"method":"sendMessage","chat_id":1111111,"text":"mason added buy milk in 1111111","reply_markup":{"inline_keyboard":[[{"text":"Done","callback_data":"done:12"}]]}}` + "\n",
+		},
+		{
+			name:     "invalid command",
+			body:     `{"update_id":10001,"message":{"message_id":43,"from":{"id":1111111},"chat":{"id":1111111,"type":"private"},"text":"/todo done"}}`,
+			token:    "secret",
+			wantCode: http.StatusOK,
+			wantBody: `{"method":"sendMessage","chat_id":1111111,"text":"required field id is missing, see /todo help for more details","reply_to_message_id":43}` + "\n",
+		},
+		{
+			name:     "other bot",
+			body:     `{"update_id":10002,"message":{"message_id":44,"chat":{"id":-100,"type":"group"},"text":"/todo@OtherBot add buy milk"}}`,
+			token:    "secret",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "not a command",
+			body:     `{"update_id":10003,"message":{"message_id":45,"chat":{"id":-100,"type":"group"},"text":"todo add buy milk"}}`,
+			token:    "secret",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "wrong secret token",
+			body:     `{"update_id":10004}`,
+			token:    "guess",
+			wantCode: http.StatusUnauthorized,
+			wantBody: "invalid secret token\n",
+		},
+	}
+
+	handler := NewHandler(newTestCommand(t), "TodoBot")
+	handler.SecretToken = "secret"
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/telegram", strings.NewReader(test.body))
+			req.Header.Set("X-Telegram-Bot-Api-Secret-Token", test.token)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, test.wantCode, rec.Code)
+			assert.Equal(t, test.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestGetBotCommands(t *testing.T) {
+	wranglerDef, _ := ioutil.ReadFile("../testData/wrangler.yaml")
+	wrangler, _ := slashparse.NewSlashCommand(wranglerDef)
+	invalid := slashparse.SlashCommand{Name: "Move-Thread"}
+
+	got, issues := GetBotCommands(newTestCommand(t), &wrangler, &invalid)
+	assert.Equal(t, SetMyCommands{Commands: []BotCommand{
+		{Command: "todo", Description: "Keep track of things to do"},
+		{Command: "wrangler", Description: "Manage Mattermost Messages Masterfully"},
+		{Command: "move-thread"},
+	}}, got)
+	assert.Equal(t, []slashparse.ExportIssue{
+		{Path: "Move-Thread", Message: "Telegram commands must be 1 to 32 lower case letters, numbers or _"},
+		{Path: "Move-Thread", Message: "Telegram requires a description"},
+	}, issues)
+}
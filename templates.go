@@ -1,5 +1,11 @@
-// THIS  FILE IS GENERATED, DO NOT EDIT, INSTEAD UPDATE templates/standardHelp.tpl and run generate/generateFromStatic.go
+// THIS  FILE IS GENERATED, DO NOT EDIT, INSTEAD UPDATE templates/*.tpl and run generate/generateFromStatic.go
 
 package slashparse
 
-const helpTemplateContent = "#### /{{.Name}} Help\n-- *{{.Description}}*\n\n`/{{ .Name | ToLower }}{{range $arg := .Arguments}} {{if not $arg.Required}}[{{end}}{{$arg.Name}}{{if not $arg.Required}}]{{end}}{{end}}`\n\n#### Arguments\n{{range $arg := .Arguments}}\n* **{{$arg.Name}}**: {{if not $arg.Required}}(optional){{end}} _{{$arg.Description}}_\n{{end}}\n#### Available Commands\n{{range $subCommand := .SubCommands }}\n* **{{$subCommand.Name}}**: _{{$subCommand.Description}}_\n  `/{{$.Name | ToLower}} {{$subCommand.Name}} {{range $arg := $subCommand.Arguments}}{{if not $arg.Required}}[{{end}}{{$arg.Name}}{{if not $arg.Required}}]{{end}}{{end}}`\n  {{range $subSubCommand := .SubCommands}}  *  **{{$subSubCommand.Name}}**: {{$subSubCommand.Description}}\n    `/{{$.Name}} {{$subCommand.Name}} {{$subSubCommand.Name}} {{range $arg := $subSubCommand.Arguments}}{{if not $arg.Required}}[{{end}}{{$arg.Name}}{{if not $arg.Required}}]{{end}}{{end}}`{{end}}\n{{end}}"
+const helpTemplateContent = "#### /{{.Path}} Help\n-- *{{.Description}}*\n{{if .Deprecated}}\n**Deprecated:** {{.Deprecated}}\n{{end}}{{if .LongDescription}}\n{{.LongDescription}}\n{{end}}\n`/{{ .Path | ToLower }}{{range $arg := .Arguments}} {{if not $arg.Required}}[{{end}}{{$arg.Name}}{{if not $arg.Required}}]{{end}}{{end}}`\n{{if .Arguments}}\n#### Arguments\n{{range $arg := .Arguments}}\n* **{{$arg.Name}}**: {{if not $arg.Required}}(optional){{end}} _{{$arg.Description}}_{{if $arg.Deprecated}} **(deprecated: {{$arg.Deprecated}})**{{end}}{{if $arg.Default}} (default: {{$arg.Default}}){{end}}\n{{end}}{{end}}{{if .Examples}}\n#### Examples\n{{range $example := .Examples}}\n* `{{$example.Command}}`{{if $example.Description}}: {{$example.Description}}{{end}}\n{{end}}{{end}}{{if .Notes}}\n#### Notes\n{{range $note := .Notes}}\n* {{$note}}\n{{end}}{{end}}{{if .SubCommands}}\n#### Available Commands\n{{range $subCommand := .SubCommands }}\n* **{{$subCommand.Name}}**: _{{$subCommand.Description}}_{{if $subCommand.Deprecated}} **(deprecated: {{$subCommand.Deprecated}})**{{end}}\n  `/{{$.Path | ToLower}} {{$subCommand.Name}} {{range $arg := $subCommand.Arguments}}{{if not $arg.Required}}[{{end}}{{$arg.Name}}{{if not $arg.Required}}]{{end}}{{end}}`\n  {{range $subSubCommand := .SubCommands}}  *  **{{$subSubCommand.Name}}**: {{$subSubCommand.Description}}{{if $subSubCommand.Deprecated}} **(deprecated: {{$subSubCommand.Deprecated}})**{{end}}\n    `/{{$.Path}} {{$subCommand.Name}} {{$subSubCommand.Name}} {{range $arg := $subSubCommand.Arguments}}{{if not $arg.Required}}[{{end}}{{$arg.Name}}{{if not $arg.Required}}]{{end}}{{end}}`{{end}}\n{{end}}{{end}}"
+
+const textHelpTemplateContent = "/{{.Path}} - {{.Description}}{{if .Deprecated}}\nDeprecated: {{.Deprecated}}{{end}}\n{{if .LongDescription}}\n{{.LongDescription | wrap 72}}\n{{end}}\nUsage: {{.Usage}}\n{{if .Arguments}}\nArguments:\n{{range .Arguments}}  {{.Name}}{{if .ShortName}} (-{{.ShortName}}){{end}}: {{.Description}}{{if .Required}} (required){{end}}{{if .Default}} (default: {{.Default}}){{end}}{{if .Deprecated}} (deprecated: {{.Deprecated}}){{end}}\n{{end}}{{end}}{{if .Examples}}\nExamples:\n{{range .Examples}}  {{.Command}}{{if .Description}}\n      {{.Description}}{{end}}\n{{end}}{{end}}{{if .Notes}}\nNotes:\n{{range .Notes}}  - {{.}}\n{{end}}{{end}}{{if .SubCommands}}\nCommands:\n{{range .SubCommands}}  {{.Usage}}\n      {{.Description}}{{if .Deprecated}} (deprecated: {{.Deprecated}}){{end}}\n{{range .SubCommands}}  {{.Usage}}\n      {{.Description}}{{if .Deprecated}} (deprecated: {{.Deprecated}}){{end}}\n{{end}}{{end}}{{end}}"
+
+const htmlHelpTemplateContent = "<div class=\"slash-help\">\n<h4>/{{.Path}} Help</h4>\n<p><em>{{.Description}}</em></p>\n{{if .Deprecated}}<p class=\"deprecated\"><strong>Deprecated:</strong> {{.Deprecated}}</p>\n{{end}}{{if .LongDescription}}<p>{{.LongDescription}}</p>\n{{end}}<pre><code>{{.Usage}}</code></pre>\n{{if .Arguments}}<h5>Arguments</h5>\n<dl>\n{{range .Arguments}}<dt>{{.Name}}{{if not .Required}} (optional){{end}}</dt>\n<dd>{{.Description}}{{if .Default}} (default: {{.Default}}){{end}}{{if .Deprecated}} <strong>(deprecated: {{.Deprecated}})</strong>{{end}}</dd>\n{{end}}</dl>\n{{end}}{{if .Examples}}<h5>Examples</h5>\n<ul>\n{{range .Examples}}<li><code>{{.Command}}</code>{{if .Description}}: {{.Description}}{{end}}</li>\n{{end}}</ul>\n{{end}}{{if .Notes}}<h5>Notes</h5>\n<ul>\n{{range .Notes}}<li>{{.}}</li>\n{{end}}</ul>\n{{end}}{{if .SubCommands}}<h5>Available Commands</h5>\n<ul>\n{{range .SubCommands}}<li><strong>{{.Name}}</strong>: {{.Description}} <code>{{.Usage}}</code>{{if .Deprecated}} <strong>(deprecated: {{.Deprecated}})</strong>{{end}}{{if .SubCommands}}\n<ul>\n{{range .SubCommands}}<li><strong>{{.Name}}</strong>: {{.Description}} <code>{{.Usage}}</code>{{if .Deprecated}} <strong>(deprecated: {{.Deprecated}})</strong>{{end}}</li>\n{{end}}</ul>{{end}}</li>\n{{end}}</ul>\n{{end}}</div>\n"
+
+const indexHelpTemplateContent = "#### Available Commands\n{{range .}}\n* **/{{.Path | ToLower}}**: _{{.Description}}_{{if .Deprecated}} **(deprecated: {{.Deprecated}})**{{end}}\n  `/{{.Path | ToLower}} help` for more details\n{{end}}"
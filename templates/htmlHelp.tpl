@@ -0,0 +1,27 @@
+<div class="slash-help">
+<h4>/{{.Path}} Help</h4>
+<p><em>{{.Description}}</em></p>
+{{if .Deprecated}}<p class="deprecated"><strong>Deprecated:</strong> {{.Deprecated}}</p>
+{{end}}{{if .LongDescription}}<p>{{.LongDescription}}</p>
+{{end}}<pre><code>{{.Usage}}</code></pre>
+{{if .Arguments}}<h5>Arguments</h5>
+<dl>
+{{range .Arguments}}<dt>{{.Name}}{{if not .Required}} (optional){{end}}</dt>
+<dd>{{.Description}}{{if .Default}} (default: {{.Default}}){{end}}{{if .Deprecated}} <strong>(deprecated: {{.Deprecated}})</strong>{{end}}</dd>
+{{end}}</dl>
+{{end}}{{if .Examples}}<h5>Examples</h5>
+<ul>
+{{range .Examples}}<li><code>{{.Command}}</code>{{if .Description}}: {{.Description}}{{end}}</li>
+{{end}}</ul>
+{{end}}{{if .Notes}}<h5>Notes</h5>
+<ul>
+{{range .Notes}}<li>{{.}}</li>
+{{end}}</ul>
+{{end}}{{if .SubCommands}}<h5>Available Commands</h5>
+<ul>
+{{range .SubCommands}}<li><strong>{{.Name}}</strong>: {{.Description}} <code>{{.Usage}}</code>{{if .Deprecated}} <strong>(deprecated: {{.Deprecated}})</strong>{{end}}{{if .SubCommands}}
+<ul>
+{{range .SubCommands}}<li><strong>{{.Name}}</strong>: {{.Description}} <code>{{.Usage}}</code>{{if .Deprecated}} <strong>(deprecated: {{.Deprecated}})</strong>{{end}}</li>
+{{end}}</ul>{{end}}</li>
+{{end}}</ul>
+{{end}}</div>
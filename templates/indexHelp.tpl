@@ -0,0 +1,5 @@
+#### Available Commands
+{{range .}}
+* **/{{.Path | ToLower}}**: _{{.Description}}_{{if .Deprecated}} **(deprecated: {{.Deprecated}})**{{end}}
+  `/{{.Path | ToLower}} help` for more details
+{{end}}
\ No newline at end of file
@@ -1,16 +1,28 @@
-#### /{{.Name}} Help
+#### /{{.Path}} Help
 -- *{{.Description}}*
-
-`/{{ .Name | ToLower }}{{range $arg := .Arguments}} {{if not $arg.Required}}[{{end}}{{$arg.Name}}{{if not $arg.Required}}]{{end}}{{end}}`
-
+{{if .Deprecated}}
+**Deprecated:** {{.Deprecated}}
+{{end}}{{if .LongDescription}}
+{{.LongDescription}}
+{{end}}
+`/{{ .Path | ToLower }}{{range $arg := .Arguments}} {{if not $arg.Required}}[{{end}}{{$arg.Name}}{{if not $arg.Required}}]{{end}}{{end}}`
+{{if .Arguments}}
 #### Arguments
 {{range $arg := .Arguments}}
-* **{{$arg.Name}}**: {{if not $arg.Required}}(optional){{end}} _{{$arg.Description}}_
-{{end}}
+* **{{$arg.Name}}**: {{if not $arg.Required}}(optional){{end}} _{{$arg.Description}}_{{if $arg.Deprecated}} **(deprecated: {{$arg.Deprecated}})**{{end}}{{if $arg.Default}} (default: {{$arg.Default}}){{end}}
+{{end}}{{end}}{{if .Examples}}
+#### Examples
+{{range $example := .Examples}}
+* `{{$example.Command}}`{{if $example.Description}}: {{$example.Description}}{{end}}
+{{end}}{{end}}{{if .Notes}}
+#### Notes
+{{range $note := .Notes}}
+* {{$note}}
+{{end}}{{end}}{{if .SubCommands}}
 #### Available Commands
 {{range $subCommand := .SubCommands }}
-* **{{$subCommand.Name}}**: _{{$subCommand.Description}}_
-  `/{{$.Name | ToLower}} {{$subCommand.Name}} {{range $arg := $subCommand.Arguments}}{{if not $arg.Required}}[{{end}}{{$arg.Name}}{{if not $arg.Required}}]{{end}}{{end}}`
-  {{range $subSubCommand := .SubCommands}}  *  **{{$subSubCommand.Name}}**: {{$subSubCommand.Description}}
-    `/{{$.Name}} {{$subCommand.Name}} {{$subSubCommand.Name}} {{range $arg := $subSubCommand.Arguments}}{{if not $arg.Required}}[{{end}}{{$arg.Name}}{{if not $arg.Required}}]{{end}}{{end}}`{{end}}
-{{end}}
\ No newline at end of file
+* **{{$subCommand.Name}}**: _{{$subCommand.Description}}_{{if $subCommand.Deprecated}} **(deprecated: {{$subCommand.Deprecated}})**{{end}}
+  `/{{$.Path | ToLower}} {{$subCommand.Name}} {{range $arg := $subCommand.Arguments}}{{if not $arg.Required}}[{{end}}{{$arg.Name}}{{if not $arg.Required}}]{{end}}{{end}}`
+  {{range $subSubCommand := .SubCommands}}  *  **{{$subSubCommand.Name}}**: {{$subSubCommand.Description}}{{if $subSubCommand.Deprecated}} **(deprecated: {{$subSubCommand.Deprecated}})**{{end}}
+    `/{{$.Path}} {{$subCommand.Name}} {{$subSubCommand.Name}} {{range $arg := $subSubCommand.Arguments}}{{if not $arg.Required}}[{{end}}{{$arg.Name}}{{if not $arg.Required}}]{{end}}{{end}}`{{end}}
+{{end}}{{end}}
\ No newline at end of file
@@ -0,0 +1,23 @@
+/{{.Path}} - {{.Description}}{{if .Deprecated}}
+Deprecated: {{.Deprecated}}{{end}}
+{{if .LongDescription}}
+{{.LongDescription | wrap 72}}
+{{end}}
+Usage: {{.Usage}}
+{{if .Arguments}}
+Arguments:
+{{range .Arguments}}  {{.Name}}{{if .ShortName}} (-{{.ShortName}}){{end}}: {{.Description}}{{if .Required}} (required){{end}}{{if .Default}} (default: {{.Default}}){{end}}{{if .Deprecated}} (deprecated: {{.Deprecated}}){{end}}
+{{end}}{{end}}{{if .Examples}}
+Examples:
+{{range .Examples}}  {{.Command}}{{if .Description}}
+      {{.Description}}{{end}}
+{{end}}{{end}}{{if .Notes}}
+Notes:
+{{range .Notes}}  - {{.}}
+{{end}}{{end}}{{if .SubCommands}}
+Commands:
+{{range .SubCommands}}  {{.Usage}}
+      {{.Description}}{{if .Deprecated}} (deprecated: {{.Deprecated}}){{end}}
+{{range .SubCommands}}  {{.Usage}}
+      {{.Description}}{{if .Deprecated}} (deprecated: {{.Deprecated}}){{end}}
+{{end}}{{end}}{{end}}
\ No newline at end of file